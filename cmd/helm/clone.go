@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/black-atom-industries/helm/internal/config"
+	"github.com/black-atom-industries/helm/internal/github"
+	"github.com/black-atom-industries/helm/internal/tmux"
+)
+
+// Exit codes of helm clone, so scripts can tell the failures apart
+const (
+	exitCloneFailed   = 1
+	exitGhMissing     = 2 // gh is not installed or not authenticated (--list only)
+	exitAlreadyCloned = 3
+)
+
+// exitError carries a specific exit code out of a subcommand
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// exitCode returns the process exit code for an error returned by a subcommand
+func exitCode(err error) int {
+	var e *exitError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return exitCloneFailed
+}
+
+// cloneRepo is a repository in `helm clone --list --json` output
+type cloneRepo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Language    string `json:"language,omitempty"`
+}
+
+// runClone executes the helm clone subcommand: list uncloned repos, or clone one
+// into the first project_dirs entry (like C-r in the TUI) and create its session
+func runClone(cfg config.Config, args []string) error {
+	if len(args) == 0 {
+		printCloneUsage()
+		return nil
+	}
+
+	if len(cfg.ProjectDirs) == 0 {
+		return fmt.Errorf("no project_dirs configured in %s", config.Path())
+	}
+	basePath := cfg.ProjectDirs[0]
+
+	if hasFlag(args, "--list") {
+		return runCloneList(cfg, basePath, hasFlag(args, "--json"))
+	}
+	return runCloneRepo(cfg, basePath, args[0])
+}
+
+func printCloneUsage() {
+	fmt.Println("Usage: helm clone <command>")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  --list [--json]     List repos you can access that aren't cloned yet")
+	fmt.Println("  <owner/repo | url>  Clone a repo and create its session")
+	fmt.Println()
+	fmt.Printf("Exit codes: %d failed, %d gh missing or not authenticated, %d already cloned\n",
+		exitCloneFailed, exitGhMissing, exitAlreadyCloned)
+}
+
+// runCloneList prints the uncloned repos, one per line or as JSON
+func runCloneList(cfg config.Config, basePath string, jsonOut bool) error {
+	if err := github.CheckGhCli(); err != nil {
+		return &exitError{code: exitGhMissing, err: err}
+	}
+
+	repos, err := github.FetchAvailableRepos(cfg.CloneDetailsEnabled)
+	if err != nil {
+		return err
+	}
+
+	available := make([]string, len(repos))
+	byName := make(map[string]github.Repo, len(repos))
+	for i, r := range repos {
+		available[i] = r.FullName
+		byName[r.FullName] = r
+	}
+	cloned, _ := config.ListClonedRepos(basePath)
+	uncloned := config.FilterUncloned(available, cloned)
+
+	if jsonOut {
+		out := struct {
+			Repos []cloneRepo `json:"repos"`
+		}{Repos: make([]cloneRepo, 0, len(uncloned))}
+		for _, name := range uncloned {
+			r := byName[name]
+			out.Repos = append(out.Repos, cloneRepo{Name: name, Description: r.Description, Language: r.Language})
+		}
+		data, _ := json.Marshal(out)
+		fmt.Println(string(data))
+		return nil
+	}
+
+	for _, name := range uncloned {
+		fmt.Println(name)
+	}
+	return nil
+}
+
+// runCloneRepo clones owner/repo (over SSH) or a git URL, creates the session
+// and applies the layout, without switching to it
+func runCloneRepo(cfg config.Config, basePath, target string) error {
+	ownerRepo, url := target, ""
+	if strings.Contains(target, "://") || strings.HasPrefix(target, "git@") {
+		ownerRepo, url = github.ParseGitURL(target), target
+	}
+	if strings.Count(ownerRepo, "/") != 1 {
+		return fmt.Errorf("expected owner/repo or a git URL, got %q", target)
+	}
+
+	destPath := filepath.Join(basePath, ownerRepo)
+	if _, err := os.Stat(destPath); err == nil {
+		return &exitError{code: exitAlreadyCloned, err: fmt.Errorf("%s is already cloned at %s", ownerRepo, destPath)}
+	}
+
+	if url != "" {
+		err := github.CloneURL(url, destPath)
+		if err != nil {
+			return err
+		}
+	} else if err := github.CloneRepo(ownerRepo, destPath); err != nil {
+		return err
+	}
+
+	sessionName := sanitizeSessionName(ownerRepo)
+	if !tmux.SessionExists(sessionName) {
+		if err := tmux.CreateSession(sessionName, destPath, cfg.SessionShell); err != nil {
+			return fmt.Errorf("cloned but failed to create session: %w", err)
+		}
+		applyLayout(cfg, sessionName, destPath)
+	}
+
+	fmt.Printf("Cloned %s to %s (session %s)\n", ownerRepo, destPath, sessionName)
+	return nil
+}
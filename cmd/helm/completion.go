@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/black-atom-industries/helm/internal/claude"
+	"github.com/black-atom-industries/helm/internal/config"
+	"github.com/black-atom-industries/helm/internal/tmux"
+)
+
+// subcommands lists the user-facing subcommands offered by shell completion
+var subcommands = []string{"monitor", "init", "config", "setup", "repos", "clone", "switch", "here", "new", "bookmark", "tmux-bindings", "check", "claude-status", "claude-clear", "completion"}
+
+// reposCommands lists the subcommands of `helm repos`
+var reposCommands = []string{"status", "pull", "push", "rebuild"}
+
+// runCompletion prints the completion script for the given shell
+func runCompletion(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: helm completion <bash|zsh|fish>")
+	}
+
+	switch args[0] {
+	case "bash":
+		fmt.Print(bashCompletion)
+	case "zsh":
+		fmt.Print(zshCompletion)
+	case "fish":
+		fmt.Print(fishCompletion)
+	default:
+		return fmt.Errorf("unsupported shell: %s (must be bash, zsh or fish)", args[0])
+	}
+	return nil
+}
+
+// runComplete prints dynamic completion candidates, one per line
+// Called by the completion scripts as `helm __complete <previous word>`
+// With a broken config (cfgErr) config-based candidates are left out
+func runComplete(args []string, cfg config.Config, cfgErr error) {
+	if len(args) == 0 {
+		for _, cmd := range subcommands {
+			fmt.Println(cmd)
+		}
+		return
+	}
+
+	switch args[0] {
+	case "here", "switch":
+		// Existing session names (helm here <name> switches to them)
+		sessions, err := tmux.ListSessions("", false)
+		if err != nil {
+			return
+		}
+		for _, s := range sessions {
+			fmt.Println(s.Name)
+		}
+	case "bookmark":
+		if cfgErr != nil {
+			return
+		}
+		for i := range cfg.Bookmarks {
+			if i > 9 {
+				break
+			}
+			fmt.Println(strconv.Itoa(i))
+		}
+	case "new":
+		fmt.Println("--template")
+	case "--template":
+		if cfgErr != nil {
+			return
+		}
+		for _, t := range cfg.Templates {
+			fmt.Println(t.Name)
+		}
+	case "repos":
+		for _, cmd := range reposCommands {
+			fmt.Println(cmd)
+		}
+	case "claude-status":
+		for _, state := range claude.States {
+			fmt.Println(state)
+		}
+		fmt.Println("clear")
+	case "clone":
+		fmt.Println("--list")
+	case "--list":
+		fmt.Println("--json")
+	case "completion":
+		fmt.Println("bash")
+		fmt.Println("zsh")
+		fmt.Println("fish")
+	}
+}
+
+const bashCompletion = `# helm bash completion
+# Add to ~/.bashrc: eval "$(helm completion bash)"
+_helm() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+    if [[ $COMP_CWORD -eq 1 ]]; then
+        COMPREPLY=($(compgen -W "$(helm __complete) --print --all --show-popups --config" -- "$cur"))
+    else
+        local IFS=$'\n'
+        COMPREPLY=($(compgen -W "$(helm __complete "${COMP_WORDS[COMP_CWORD-1]}")" -- "$cur"))
+    fi
+}
+complete -F _helm helm
+`
+
+const zshCompletion = `#compdef helm
+# helm zsh completion
+# Add to ~/.zshrc: eval "$(helm completion zsh)"
+_helm() {
+    local -a candidates
+    if (( CURRENT == 2 )); then
+        candidates=(${(f)"$(helm __complete)"} --print --all --show-popups --config)
+    else
+        candidates=(${(f)"$(helm __complete "${words[CURRENT-1]}")"})
+    fi
+    compadd -a candidates
+}
+compdef _helm helm
+`
+
+const fishCompletion = `# helm fish completion
+# Add to fish config: helm completion fish | source
+complete -c helm -f
+complete -c helm -n "__fish_use_subcommand" -l print -d "Print selection instead of switching"
+complete -c helm -n "__fish_use_subcommand" -l all -d "Also list _popup_ sessions"
+complete -c helm -n "__fish_use_subcommand" -l show-popups -d "Also list _popup_ sessions"
+complete -c helm -n "__fish_use_subcommand" -l config -r -F -d "Use another config file"
+complete -c helm -n "__fish_use_subcommand" -a "(helm __complete)"
+complete -c helm -n "__fish_seen_subcommand_from here switch new bookmark repos clone claude-status completion" -a "(helm __complete (commandline -opc)[-1])"
+`
@@ -1,17 +1,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/black-atom-industries/helm/internal/claude"
 	"github.com/black-atom-industries/helm/internal/config"
+	"github.com/black-atom-industries/helm/internal/git"
+	"github.com/black-atom-industries/helm/internal/log"
 	"github.com/black-atom-industries/helm/internal/model"
+	"github.com/black-atom-industries/helm/internal/shell"
 	"github.com/black-atom-industries/helm/internal/tmux"
 )
 
@@ -22,9 +28,41 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Handle subcommands
-	if len(os.Args) > 1 {
+	// --config applies to every subcommand, so take it out before dispatching
+	args, configPath, err := splitConfigFlag(os.Args[1:])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if configPath != "" {
+		config.SetPath(configPath)
+	}
+	os.Args = append(os.Args[:1], args...)
+
+	// Load the config once for every subcommand. Every subcommand talks to tmux,
+	// so pick the server (socket / HELM_SOCKET) first
+	// A broken config is reported by the command that needs it (see needConfig)
+	cfg, cfgErr := config.Load()
+	if cfgErr == nil {
+		tmux.SetSocket(cfg.Socket)
+	}
+	needConfig := func() config.Config {
+		if cfgErr != nil {
+			fmt.Printf("Error: failed to load config: %v\n", cfgErr)
+			os.Exit(1)
+		}
+		return cfg
+	}
+
+	// Handle subcommands (flags are passed through to the TUI)
+	tuiArgs := os.Args[1:]
+	monitor := false
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
 		switch os.Args[1] {
+		case "monitor":
+			// Read-only dashboard: runs the TUI below with destructive actions disabled
+			monitor = true
+			tuiArgs = os.Args[2:]
 		case "init":
 			if err := config.Init(); err != nil {
 				fmt.Printf("Error: %v\n", err)
@@ -32,53 +70,121 @@ func main() {
 			}
 			fmt.Printf("Created config file at %s\n", config.Path())
 			return
+		case "config":
+			if err := runConfigEdit(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
 		case "bookmark":
 			if len(os.Args) < 3 {
 				fmt.Println("Usage: helm bookmark <N>")
 				fmt.Println("Opens bookmark at slot N (0-9)")
 				os.Exit(1)
 			}
-			if err := runBookmark(os.Args[2]); err != nil {
+			if err := runBookmark(needConfig(), os.Args[2]); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "switch":
+			if len(os.Args) < 3 {
+				fmt.Println("Usage: helm switch <session[:window]>")
+				os.Exit(1)
+			}
+			if err := tmux.SwitchClient(os.Args[2]); err != nil {
+				fmt.Printf("Error: can't switch to %s: %v\n", os.Args[2], err)
+				os.Exit(1)
+			}
+			return
+		case "here":
+			if err := runHere(needConfig(), os.Args[2:]); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "new":
+			if err := runNew(needConfig(), os.Args[2:]); err != nil {
 				fmt.Printf("Error: %v\n", err)
 				os.Exit(1)
 			}
 			return
 		case "tmux-bindings":
-			if err := printTmuxBindings(); err != nil {
+			if err := printTmuxBindings(needConfig()); err != nil {
 				fmt.Printf("Error: %v\n", err)
 				os.Exit(1)
 			}
 			return
 		case "setup":
-			if err := runSetup(); err != nil {
+			if err := runSetup(needConfig()); err != nil {
 				fmt.Printf("Error: %v\n", err)
 				os.Exit(1)
 			}
 			return
 		case "repos":
-			if err := runRepos(os.Args[2:]); err != nil {
+			if err := runRepos(needConfig(), os.Args[2:]); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "clone":
+			if err := runClone(needConfig(), os.Args[2:]); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			return
+		case "check":
+			if err := runCheck(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "claude-status":
+			if err := runClaudeStatus(needConfig(), os.Args[2:]); err != nil {
 				fmt.Printf("Error: %v\n", err)
 				os.Exit(1)
 			}
 			return
+		case "claude-clear":
+			if err := runClaudeClear(needConfig()); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "completion":
+			if err := runCompletion(os.Args[2:]); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "__complete":
+			runComplete(os.Args[2:], cfg, cfgErr)
+			return
 		default:
 			fmt.Printf("Unknown command: %s\n", os.Args[1])
-			fmt.Println("Usage: helm [init | setup | repos | bookmark <N> | tmux-bindings]")
+			fmt.Println("Usage: helm [--config <path>] [--print] [--all] [monitor | init | config | setup | repos | clone [--list [--json] | <owner/repo>] | switch <target> | here [name] | new [--template <t>] [name] [-- cmd] | bookmark <N> | tmux-bindings | check | claude-status <state> [session] | claude-clear | completion <shell>]")
 			os.Exit(1)
 		}
 	}
 
+	opts, err := parseTUIFlags(tuiArgs)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Check if running inside tmux
 	if os.Getenv("TMUX") == "" {
 		fmt.Println("Error: helm must be run from within tmux")
 		os.Exit(1)
 	}
 
-	// Load configuration
-	cfg, err := config.Load()
-	if err != nil {
-		fmt.Printf("Error loading config: %v\n", err)
-		os.Exit(1)
+	cfg = needConfig()
+	if cfg.Debug {
+		if err := log.Open(cfg.CacheDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		defer log.Close()
 	}
 
 	// Get current session to exclude from list
@@ -90,26 +196,96 @@ func main() {
 
 	// Initialize and run the TUI
 	m := model.New(currentSession, cfg)
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	programOpts := []tea.ProgramOption{tea.WithAltScreen()}
+	m.SetReadOnly(monitor)
+	m.SetShowPopups(opts.all)
+	if opts.print {
+		// Keep stdout clean for the selection; draw the UI on stderr
+		m.SetPrintSelection(true)
+		programOpts = append(programOpts, tea.WithOutput(os.Stderr))
+	}
+	p := tea.NewProgram(m, programOpts...)
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
 	}
+
+	if opts.print {
+		selection := selectionOf(final)
+		if selection == "" {
+			os.Exit(1)
+		}
+		fmt.Println(selection)
+	}
+}
+
+// splitConfigFlag removes "--config <path>" (or "--config=<path>") from args
+// Returns the remaining args and the path ("" if the flag isn't given)
+// Arguments after "--" are a command (helm new name -- cmd) and kept as they are
+func splitConfigFlag(args []string) ([]string, string, error) {
+	var rest []string
+	path := ""
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--":
+			return append(rest, args[i:]...), path, nil
+		case args[i] == "--config":
+			if i+1 >= len(args) {
+				return nil, "", fmt.Errorf("--config requires a path")
+			}
+			path = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--config="):
+			path = strings.TrimPrefix(args[i], "--config=")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	return rest, path, nil
+}
+
+// tuiOptions holds flags that change how the TUI runs
+type tuiOptions struct {
+	print bool // Print the selected target to stdout instead of switching
+	all   bool // Also list _popup_ sessions (--all / --show-popups), e.g. to kill orphans
+}
+
+// parseTUIFlags parses TUI flags; HELM_PRINT=1 is equivalent to --print
+func parseTUIFlags(args []string) (tuiOptions, error) {
+	opts := tuiOptions{print: os.Getenv("HELM_PRINT") == "1"}
+	for _, arg := range args {
+		switch arg {
+		case "--print":
+			opts.print = true
+		case "--all", "--show-popups":
+			opts.all = true
+		default:
+			return opts, fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+	return opts, nil
+}
+
+// selectionOf extracts the print-mode selection from the final program model
+func selectionOf(final tea.Model) string {
+	switch fm := final.(type) {
+	case model.Model:
+		return fm.Selection()
+	case *model.Model:
+		return fm.Selection()
+	}
+	return ""
 }
 
 // runBookmark opens the bookmark at slot N (0-9)
-func runBookmark(slotStr string) error {
+func runBookmark(cfg config.Config, slotStr string) error {
 	slot, err := strconv.Atoi(slotStr)
 	if err != nil || slot < 0 || slot > 9 {
 		return fmt.Errorf("invalid slot: %s (must be 0-9)", slotStr)
 	}
 
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
 	if slot >= len(cfg.Bookmarks) {
 		return fmt.Errorf("no bookmark at slot %d", slot)
 	}
@@ -119,40 +295,302 @@ func runBookmark(slotStr string) error {
 
 	// Create session if it doesn't exist
 	if !tmux.SessionExists(sessionName) {
-		if err := tmux.CreateSession(sessionName, bookmark.Path); err != nil {
+		if err := tmux.CreateSession(sessionName, bookmark.Path, cfg.SessionShell); err != nil {
+			return fmt.Errorf("failed to create session: %w", err)
+		}
+
+		applyLayout(cfg, sessionName, bookmark.Path)
+	}
+
+	// Switch to the session
+	return tmux.SwitchClient(sessionName)
+}
+
+// runHere creates (or switches to) a session rooted at the current directory
+// The session name defaults to the last ProjectDepth components of $PWD
+func runHere(cfg config.Config, args []string) error {
+	dir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	sessionName := extractSessionName(dir, cfg.ProjectDepth)
+	if len(args) > 0 && strings.TrimSpace(args[0]) != "" {
+		sessionName = sanitizeSessionName(strings.TrimSpace(args[0]))
+	}
+
+	if !tmux.SessionExists(sessionName) {
+		if err := tmux.CreateSession(sessionName, dir, cfg.SessionShell); err != nil {
 			return fmt.Errorf("failed to create session: %w", err)
 		}
+		applyLayout(cfg, sessionName, dir)
+	}
+
+	return tmux.SwitchClient(sessionName)
+}
 
-		// Apply layout if configured
-		if cfg.Layout != "" && cfg.LayoutDir != "" {
-			layoutPath := filepath.Join(cfg.LayoutDir, cfg.Layout+".sh")
-			if _, err := os.Stat(layoutPath); err == nil {
-				cmd := exec.Command(layoutPath, sessionName, bookmark.Path)
-				cmd.Env = append(os.Environ(),
-					"TMUX_SESSION="+sessionName,
-					"TMUX_WORKING_DIR="+bookmark.Path,
-				)
-				_ = cmd.Run()
+// runConfigEdit opens the config file in $EDITOR, creating it with commented defaults
+// first if it doesn't exist yet, and checks it once the editor exits
+func runConfigEdit() error {
+	editor, err := config.Editor()
+	if err != nil {
+		return fmt.Errorf("%w; set it to edit %s", err, config.Path())
+	}
+	if !config.Exists() {
+		if err := config.Init(); err != nil {
+			return err
+		}
+	}
+
+	// Through the shell, so editors with arguments ("code --wait") work
+	cmd := exec.Command("sh", "-c", editor+` "$1"`, "sh", config.Path())
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s exited: %w", editor, err)
+	}
+	return runCheck()
+}
+
+// runCheck validates the config file and settings.yml and prints a report of
+// all problems, followed by whether git is installed
+func runCheck() error {
+	if !config.Exists() {
+		fmt.Printf("No config file at %s (using defaults)\n", config.Path())
+	}
+	reports, err := config.Check()
+	if err != nil {
+		return err
+	}
+
+	errCount, warnCount := 0, 0
+	for _, report := range reports {
+		fmt.Printf("Checking %s\n", report.Path)
+		for _, p := range report.Problems {
+			if p.Warning {
+				warnCount++
+				fmt.Printf("  warning: %s\n", p)
+			} else {
+				errCount++
+				fmt.Printf("  error:   %s\n", p)
 			}
 		}
+
+		if len(report.Problems) == 0 {
+			fmt.Println("  ok")
+		}
+	}
+
+	// Checked on its own: git_status_enabled may also come from settings.yml or
+	// HELM_GIT_STATUS_ENABLED, and helm repos needs git either way
+	if git.Available() {
+		fmt.Println("git: ok")
+	} else {
+		warnCount++
+		fmt.Println("git: warning: not installed; the git column stays hidden and helm repos won't work")
+	}
+
+	if errCount > 0 {
+		return fmt.Errorf("%d error(s), %d warning(s)", errCount, warnCount)
+	}
+	if warnCount > 0 {
+		fmt.Printf("%d warning(s)\n", warnCount)
+	}
+	return nil
+}
+
+// runClaudeStatus writes a Claude Code status file, for use in Claude Code hooks
+// Usage: helm claude-status <state|clear> [session]
+// The session defaults to the current tmux session; "clear" removes the file
+func runClaudeStatus(cfg config.Config, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: helm claude-status <%s|clear> [session]", strings.Join(claude.States, "|"))
+	}
+	state := args[0]
+	if state != "clear" && !claude.ValidState(state) {
+		return fmt.Errorf("unknown state %q (must be one of %s, or clear)", state, strings.Join(claude.States, ", "))
+	}
+
+	var sessionName string
+	if len(args) > 1 {
+		sessionName = args[1]
+	} else {
+		current, err := tmux.CurrentSession()
+		if err != nil || current == "" {
+			return fmt.Errorf("not inside a tmux session (pass the session name)")
+		}
+		sessionName = current
+	}
+
+	if state == "clear" {
+		return claude.RemoveStatus(sessionName, cfg.CacheDir)
+	}
+	return claude.WriteStatus(sessionName, cfg.CacheDir, state, claude.Format(cfg.ClaudeStatusFormat))
+}
+
+// runClaudeClear removes every Claude Code status file, e.g. when states got stuck
+// Unlike the cleanup on each load, this also clears sessions that still exist
+func runClaudeClear(cfg config.Config) error {
+	removed, err := claude.ClearAll(cfg.CacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to clear statuses: %w", err)
+	}
+	fmt.Printf("Removed %d status file(s) from %s\n", removed, cfg.CacheDir)
+	return nil
+}
+
+// runNew creates a session in the current directory from a config template and/or
+// running a command, and switches to it
+// Usage: helm new [--template <name>] [session-name] [-- command...]
+func runNew(cfg config.Config, args []string) error {
+	var templateName, sessionName, command string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--":
+			// Joined like ssh does, so "tail -f app.log" and "make dev | tee log" both work
+			command = strings.Join(args[i+1:], " ")
+			i = len(args)
+		case args[i] == "--template" || args[i] == "-t":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--template requires a name")
+			}
+			i++
+			templateName = args[i]
+		case strings.HasPrefix(args[i], "--template="):
+			templateName = strings.TrimPrefix(args[i], "--template=")
+		case strings.HasPrefix(args[i], "-"):
+			return fmt.Errorf("unknown flag: %s", args[i])
+		default:
+			sessionName = sanitizeSessionName(args[i])
+		}
+	}
+	if templateName == "" && command == "" {
+		return fmt.Errorf("usage: helm new [--template <name>] [session-name] [-- command...]")
+	}
+
+	var tpl config.Template
+	if templateName != "" {
+		var ok bool
+		if tpl, ok = cfg.FindTemplate(templateName); !ok {
+			return fmt.Errorf("no template named %q", templateName)
+		}
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	if sessionName == "" {
+		sessionName = extractSessionName(dir, cfg.ProjectDepth)
+	}
+	if tmux.SessionExists(sessionName) {
+		return fmt.Errorf("session %q already exists", sessionName)
+	}
+
+	if err := tmux.CreateSession(sessionName, dir, cfg.SessionShell); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	if templateName != "" {
+		if err := applyTemplate(tpl, sessionName, dir); err != nil {
+			return fmt.Errorf("failed to apply template %q: %w", tpl.Name, err)
+		}
+	}
+	if command != "" {
+		if err := tmux.RunInSession(sessionName, command); err != nil {
+			return fmt.Errorf("failed to run %q: %w", command, err)
+		}
 	}
 
-	// Switch to the session
 	return tmux.SwitchClient(sessionName)
 }
 
+// applyTemplate turns a freshly created session into the template's window set
+// The session's initial window becomes the first template window
+func applyTemplate(tpl config.Template, sessionName, dir string) error {
+	windows, err := tmux.ListWindows(sessionName)
+	if err != nil || len(windows) == 0 {
+		return fmt.Errorf("failed to list windows: %w", err)
+	}
+
+	for i, w := range tpl.Windows {
+		windowDir := w.ResolveDir(dir)
+		var index int
+		var command string
+		if i == 0 {
+			index = windows[0].Index
+			if err := tmux.RenameWindow(sessionName, index, w.Name); err != nil {
+				return err
+			}
+			// The initial window started in the session dir
+			if windowDir != dir {
+				command = "cd " + shell.Quote(windowDir)
+			}
+		} else {
+			index, err = tmux.CreateWindow(sessionName, w.Name, windowDir)
+			if err != nil {
+				return err
+			}
+		}
+
+		if w.Command != "" {
+			if command != "" {
+				command += " && "
+			}
+			command += w.Command
+		}
+		if command != "" {
+			if err := tmux.SendKeys(sessionName, index, command); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyLayout runs the configured layout script for a freshly created session
+func applyLayout(cfg config.Config, sessionName, workingDir string) {
+	if cfg.Layout == "" || cfg.LayoutDir == "" {
+		return
+	}
+	layoutPath := filepath.Join(cfg.LayoutDir, cfg.Layout+".sh")
+	if _, err := os.Stat(layoutPath); err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.LayoutTimeoutDuration())
+	defer cancel()
+	cmd := exec.CommandContext(ctx, layoutPath, sessionName, workingDir)
+	cmd.Env = append(os.Environ(),
+		"TMUX_SESSION="+sessionName,
+		"TMUX_WORKING_DIR="+workingDir,
+	)
+	cmd.WaitDelay = time.Second
+	// Report failures but keep going: the session exists and is still switched to
+	out, err := cmd.CombinedOutput()
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		fmt.Printf("Layout '%s' timed out after %s\n", cfg.Layout, cfg.LayoutTimeoutDuration())
+	case err != nil:
+		fmt.Printf("Layout '%s' failed: %v\n", cfg.Layout, err)
+		fmt.Print(string(out))
+	}
+}
+
 // printTmuxBindings outputs tmux bind commands for configured bookmarks
 // Uses Alt+Shift+number keybindings (M-) through M-()
-func printTmuxBindings() error {
+func printTmuxBindings(cfg config.Config) error {
 	// Shifted number keys: 0=) 1=! 2=@ 3=# 4=$ 5=% 6=^ 7=& 8=* 9=(
 	shiftedKeys := []string{")", "!", "@", "#", "$", "%", "^", "&", "*", "("}
 
-	fmt.Println("# helm bookmark bindings (Alt+Shift+0-9)")
+	fmt.Println("# helm tmux bindings")
 	fmt.Println("# Add to your tmux.conf or source with: run-shell \"helm tmux-bindings | tmux source-stdin\"")
 
-	// Always output all 10 slots
+	// Popup to open helm itself (helm_popup in config)
+	if popup := cfg.HelmPopup; popup.Key != "" {
+		fmt.Printf("bind -n %s display-popup -w%s -h%s -B -E \"%s\"\n", popup.Key, popup.Width, popup.Height, config.Command())
+	}
+
+	// Bookmarks (Alt+Shift+0-9); always output all 10 slots
 	for i := 0; i < 10; i++ {
-		fmt.Printf("bind -n M-%s run-shell \"helm bookmark %d\"\n", shiftedKeys[i], i)
+		fmt.Printf("bind -n M-%s run-shell \"%s bookmark %d\"\n", shiftedKeys[i], config.Command(), i)
 	}
 
 	return nil
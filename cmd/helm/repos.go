@@ -22,9 +22,10 @@ type repoStatus struct {
 	Ahead  int    `json:"ahead"`
 	Behind int    `json:"behind"`
 	Dirty  int    `json:"dirty"`
+	Base   string `json:"base,omitempty"` // Default branch ahead/behind count against (no upstream)
 }
 
-func runRepos(args []string) error {
+func runRepos(cfg config.Config, args []string) error {
 	if len(args) == 0 {
 		printReposUsage()
 		return nil
@@ -32,13 +33,13 @@ func runRepos(args []string) error {
 
 	switch args[0] {
 	case "status":
-		return runReposStatus(args[1:])
+		return runReposStatus(cfg, args[1:])
 	case "pull":
-		return runReposPull(args[1:])
+		return runReposPull(cfg, args[1:])
 	case "push":
-		return runReposPush(args[1:])
+		return runReposPush(cfg, args[1:])
 	case "rebuild":
-		return runReposRebuild(args[1:])
+		return runReposRebuild(cfg, args[1:])
 	default:
 		fmt.Printf("Unknown repos command: %s\n", args[0])
 		printReposUsage()
@@ -102,6 +103,7 @@ func collectRepoStatuses(repos []config.RepoInfo) []repoStatus {
 				Ahead:  sync.Ahead,
 				Behind: sync.Behind,
 				Dirty:  sync.Dirty,
+				Base:   sync.Base,
 			}
 		}(i, repo)
 	}
@@ -112,14 +114,9 @@ func collectRepoStatuses(repos []config.RepoInfo) []repoStatus {
 
 // --- status ---
 
-func runReposStatus(args []string) error {
+func runReposStatus(cfg config.Config, args []string) error {
 	jsonOut := hasFlag(args, "--json")
 
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
 	repos, err := config.ListAllRepos(cfg.ProjectDirs)
 	if err != nil {
 		return fmt.Errorf("failed to list repos: %w", err)
@@ -174,6 +171,9 @@ func runReposStatus(args []string) error {
 			if s.Behind > 0 {
 				parts = append(parts, fmt.Sprintf("↓%d", s.Behind))
 			}
+			if s.Base != "" && (s.Ahead > 0 || s.Behind > 0) {
+				parts[len(parts)-1] += " vs " + s.Base
+			}
 			detail = " (" + strings.Join(parts, ", ") + ")"
 		}
 		fmt.Printf("  %s %-40s %s%s\n", sym, s.Name, s.Branch, detail)
@@ -191,14 +191,9 @@ type pullResult struct {
 	Error  string `json:"error,omitempty"`
 }
 
-func runReposPull(args []string) error {
+func runReposPull(cfg config.Config, args []string) error {
 	jsonOut := hasFlag(args, "--json")
 
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
 	repos, err := config.ListAllRepos(cfg.ProjectDirs)
 	if err != nil {
 		return fmt.Errorf("failed to list repos: %w", err)
@@ -330,14 +325,9 @@ type pushResult struct {
 	Error string `json:"error,omitempty"`
 }
 
-func runReposPush(args []string) error {
+func runReposPush(cfg config.Config, args []string) error {
 	jsonOut := hasFlag(args, "--json")
 
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
 	repos, err := config.ListAllRepos(cfg.ProjectDirs)
 	if err != nil {
 		return fmt.Errorf("failed to list repos: %w", err)
@@ -433,12 +423,7 @@ func runReposPush(args []string) error {
 
 // --- rebuild ---
 
-func runReposRebuild(args []string) error {
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
+func runReposRebuild(cfg config.Config, args []string) error {
 	if len(cfg.EnsureCloned) == 0 {
 		fmt.Println("No ensure_cloned entries in config.")
 		return nil
@@ -22,12 +22,7 @@ type setupResult struct {
 
 // runSetup executes the helm setup subcommand.
 // Clones all repositories from ensure_cloned config with parallel execution.
-func runSetup() error {
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
+func runSetup(cfg config.Config) error {
 	if len(cfg.EnsureCloned) == 0 {
 		fmt.Println("No ensure_cloned entries in config.")
 		fmt.Printf("Add repositories to %s under the ensure_cloned key.\n", config.Path())
@@ -1,6 +1,8 @@
 package claude
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -12,14 +14,48 @@ import (
 // If Claude Code hasn't updated the status file in this time, assume it's not running.
 const StaleThreshold = 2 * time.Minute
 
+// Format is the layout of a status file
+type Format string
+
+const (
+	// FormatColon is "state:unixtimestamp" as written by hooks/helm-hook.sh (default)
+	FormatColon Format = "colon"
+	// FormatJSON is {"state":"working","ts":unixtimestamp}
+	FormatJSON Format = "json"
+)
+
+// States lists the status values written by hooks and understood by the UI
+var States = []string{"new", "working", "waiting"}
+
+// ValidState reports whether state is one of States
+func ValidState(state string) bool {
+	for _, s := range States {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
 // Status represents Claude Code status for a session
 type Status struct {
 	State     string    // "new", "working", "waiting", or ""
 	Timestamp time.Time // When the status was last updated
 }
 
+// Options controls how status files are read. The zero value uses the defaults.
+type Options struct {
+	Format         Format        // Status file format (default FormatColon)
+	StaleThreshold time.Duration // When "working" is considered stale (default StaleThreshold)
+}
+
 // IsStale returns true if the status hasn't been updated within the appropriate threshold.
 func (s Status) IsStale() bool {
+	return s.IsStaleAfter(StaleThreshold)
+}
+
+// IsStaleAfter is IsStale with a custom threshold for the "working" state.
+func (s Status) IsStaleAfter(threshold time.Duration) bool {
 	if s.State == "" {
 		return false // No status to be stale
 	}
@@ -27,40 +63,129 @@ func (s Status) IsStale() bool {
 	if s.State == "waiting" {
 		return false
 	}
-	return time.Since(s.Timestamp) > StaleThreshold
+	return time.Since(s.Timestamp) > threshold
 }
 
 // GetStatus reads the Claude Code status for a session from the given cache directory.
-// Returns empty Status if no status file exists or if status is stale.
-func GetStatus(sessionName string, cacheDir string) Status {
+// Returns empty Status if no status file exists, it can't be parsed, or status is stale.
+func GetStatus(sessionName string, cacheDir string, opts Options) Status {
 	statusFile := filepath.Join(cacheDir, sessionName+".status")
 	content, err := os.ReadFile(statusFile)
 	if err != nil {
 		return Status{}
 	}
 
-	// Parse format: "state:timestamp"
+	var status Status
+	var ok bool
+	switch opts.Format {
+	case FormatJSON:
+		status, ok = parseJSON(content)
+	default:
+		status, ok = parseColon(content)
+	}
+	if !ok {
+		return Status{}
+	}
+
+	// If status is stale, treat it as no status
+	threshold := opts.StaleThreshold
+	if threshold <= 0 {
+		threshold = StaleThreshold
+	}
+	if status.IsStaleAfter(threshold) {
+		return Status{}
+	}
+
+	return status
+}
+
+// parseColon parses "state:timestamp"
+func parseColon(content []byte) (Status, bool) {
 	parts := strings.SplitN(strings.TrimSpace(string(content)), ":", 2)
 	if len(parts) != 2 {
-		return Status{}
+		return Status{}, false
 	}
 
 	timestamp, err := strconv.ParseInt(parts[1], 10, 64)
 	if err != nil {
-		return Status{}
+		return Status{}, false
 	}
 
-	status := Status{
+	return Status{
 		State:     parts[0],
 		Timestamp: time.Unix(timestamp, 0),
+	}, true
+}
+
+// parseJSON parses {"state":"working","ts":timestamp}
+func parseJSON(content []byte) (Status, bool) {
+	var raw struct {
+		State string `json:"state"`
+		TS    int64  `json:"ts"`
+	}
+	if err := json.Unmarshal(content, &raw); err != nil || raw.State == "" || raw.TS == 0 {
+		return Status{}, false
 	}
 
-	// If status is stale, treat it as no status
-	if status.IsStale() {
-		return Status{}
+	return Status{
+		State:     raw.State,
+		Timestamp: time.Unix(raw.TS, 0),
+	}, true
+}
+
+// WriteStatus records state for a session with the current time, in the given format
+func WriteStatus(sessionName, cacheDir, state string, format Format) error {
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return err
 	}
 
-	return status
+	now := time.Now().Unix()
+	var content []byte
+	switch format {
+	case FormatJSON:
+		content, _ = json.Marshal(struct {
+			State string `json:"state"`
+			TS    int64  `json:"ts"`
+		}{state, now})
+	default:
+		content = []byte(fmt.Sprintf("%s:%d", state, now))
+	}
+	content = append(content, '\n')
+
+	return os.WriteFile(filepath.Join(cacheDir, sessionName+".status"), content, 0644)
+}
+
+// RemoveStatus deletes a session's status file (missing files are not an error)
+func RemoveStatus(sessionName, cacheDir string) error {
+	err := os.Remove(filepath.Join(cacheDir, sessionName+".status"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// ClearAll removes every status file in cacheDir, including those of live sessions
+// Returns how many were removed; a missing cacheDir is not an error
+func ClearAll(cacheDir string) (int, error) {
+	entries, err := os.ReadDir(cacheDir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".status") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(cacheDir, entry.Name())); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
 }
 
 // CleanupStale removes status files for sessions that no longer exist
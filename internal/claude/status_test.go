@@ -93,7 +93,7 @@ func TestGetStatus(t *testing.T) {
 				sessionName = "nonexistent"
 			}
 
-			status := GetStatus(sessionName, tmpDir)
+			status := GetStatus(sessionName, tmpDir, Options{})
 
 			if status.State != tt.wantState {
 				t.Errorf("State = %q, want %q", status.State, tt.wantState)
@@ -110,6 +110,68 @@ func TestGetStatus(t *testing.T) {
 	}
 }
 
+func TestGetStatusJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name      string
+		content   string
+		wantState string
+	}{
+		{name: "valid json", content: fmt.Sprintf(`{"state":"working","ts":%d}`, now), wantState: "working"},
+		{name: "missing ts", content: `{"state":"working"}`, wantState: ""},
+		{name: "colon format is rejected", content: fmt.Sprintf("working:%d", now), wantState: ""},
+		{name: "stale working", content: fmt.Sprintf(`{"state":"working","ts":%d}`, now-3600), wantState: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(filepath.Join(tmpDir, "s.status"), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if got := GetStatus("s", tmpDir, Options{Format: FormatJSON}).State; got != tt.wantState {
+				t.Errorf("State = %q, want %q", got, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestGetStatusStaleThreshold(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := fmt.Sprintf("working:%d", time.Now().Add(-5*time.Minute).Unix())
+	if err := os.WriteFile(filepath.Join(tmpDir, "s.status"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := GetStatus("s", tmpDir, Options{}).State; got != "" {
+		t.Errorf("default threshold: State = %q, want stale (empty)", got)
+	}
+	if got := GetStatus("s", tmpDir, Options{StaleThreshold: 10 * time.Minute}).State; got != "working" {
+		t.Errorf("10m threshold: State = %q, want %q", got, "working")
+	}
+}
+
+func TestWriteStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, format := range []Format{FormatColon, FormatJSON} {
+		if err := WriteStatus("s", tmpDir, "waiting", format); err != nil {
+			t.Fatalf("WriteStatus(%s) error: %v", format, err)
+		}
+		if got := GetStatus("s", tmpDir, Options{Format: format}); got.State != "waiting" {
+			t.Errorf("%s round trip: State = %q, want %q", format, got.State, "waiting")
+		}
+	}
+
+	if err := RemoveStatus("s", tmpDir); err != nil {
+		t.Fatalf("RemoveStatus() error: %v", err)
+	}
+	if err := RemoveStatus("s", tmpDir); err != nil {
+		t.Errorf("RemoveStatus() on missing file error: %v", err)
+	}
+}
+
 func TestCleanupStale(t *testing.T) {
 	// Create temp directory
 	tmpDir, err := os.MkdirTemp("", "claude-cleanup-test")
@@ -149,3 +211,27 @@ func TestCleanupStale(t *testing.T) {
 		t.Error("notastatus.txt should not be deleted")
 	}
 }
+
+func TestClearAll(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, f := range []string{"active.status", "other.status", "debug.log"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, f), []byte("working:1\n"), 0644); err != nil {
+			t.Fatalf("Failed to create test file %s: %v", f, err)
+		}
+	}
+
+	removed, err := ClearAll(tmpDir)
+	if err != nil || removed != 2 {
+		t.Fatalf("ClearAll() = %d, %v, want 2 removed", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "active.status")); !os.IsNotExist(err) {
+		t.Error("active.status should be deleted")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "debug.log")); err != nil {
+		t.Error("debug.log should not be touched")
+	}
+
+	if removed, err := ClearAll(filepath.Join(tmpDir, "missing")); err != nil || removed != 0 {
+		t.Errorf("ClearAll(missing dir) = %d, %v, want 0, nil", removed, err)
+	}
+}
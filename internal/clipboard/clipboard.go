@@ -0,0 +1,41 @@
+package clipboard
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// tools are the clipboard commands tried in order; the first one on PATH is used
+var tools = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+}
+
+// lookPath is exec.LookPath, replaceable in tests
+var lookPath = exec.LookPath
+
+// command returns the clipboard command to use
+// Falls back to a tmux buffer (-w also sets the terminal clipboard via OSC 52
+// when tmux's set-clipboard allows it)
+func command() []string {
+	for _, tool := range tools {
+		if _, err := lookPath(tool[0]); err == nil {
+			return tool
+		}
+	}
+	return []string{"tmux", "load-buffer", "-w", "-"}
+}
+
+// Copy puts text on the system clipboard
+func Copy(text string) error {
+	args := command()
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to copy with %s: %w", args[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package clipboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	orig := lookPath
+	t.Cleanup(func() { lookPath = orig })
+
+	available := map[string]bool{}
+	lookPath = func(name string) (string, error) {
+		if available[name] {
+			return "/usr/bin/" + name, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	if got := strings.Join(command(), " "); got != "tmux load-buffer -w -" {
+		t.Errorf("command() without tools = %q, want the tmux fallback", got)
+	}
+
+	available["xsel"] = true
+	available["wl-copy"] = true
+	if got := command()[0]; got != "wl-copy" {
+		t.Errorf("command() = %q, want the first available tool (wl-copy)", got)
+	}
+}
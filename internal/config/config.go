@@ -1,12 +1,18 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/black-atom-industries/helm/internal/project"
+	"github.com/black-atom-industries/helm/internal/shell"
 )
 
 // Config holds all configuration options for helm
@@ -17,32 +23,173 @@ type Config struct {
 	// Directory containing layout scripts
 	LayoutDir string `yaml:"layout_dir"`
 
+	// Kill the layout script if it runs longer than this (e.g. "10s"; default DefaultLayoutTimeout)
+	LayoutTimeout string `yaml:"layout_timeout"`
+
+	// Command the first window of new sessions runs instead of tmux's default shell (e.g. "fish")
+	SessionShell string `yaml:"session_shell"`
+
+	// tmux server to manage: a socket name (tmux -L) or, if it contains a "/", a socket path (tmux -S)
+	// Empty uses the server of the current client ($TMUX) or tmux's default socket
+	Socket string `yaml:"socket"`
+
 	// Enable Claude Code status integration
 	ClaudeStatusEnabled bool `yaml:"claude_status_enabled"`
 
+	// Status file format written by the hook: "colon" (state:timestamp, default) or "json"
+	ClaudeStatusFormat string `yaml:"claude_status_format"`
+
+	// Durations (e.g. "2m", "90s"): when "working" goes stale, and when "waiting" escalates from ? to !
+	ClaudeStaleThreshold string `yaml:"claude_stale_threshold"`
+	ClaudeWaitThreshold  string `yaml:"claude_wait_threshold"`
+
 	// Enable git status indicator in session list
 	GitStatusEnabled bool `yaml:"git_status_enabled"`
 
+	// Show a colored activity dot (green <5m, yellow <1h, dim older) before the time column
+	ActivityIndicatorEnabled bool `yaml:"activity_indicator_enabled"`
+
+	// Show an AGE column with how long ago each session was created
+	SessionAgeEnabled bool `yaml:"session_age_enabled"`
+
+	// Show a project type icon (go, js, rs, ...) before each session name
+	ProjectIconsEnabled bool `yaml:"project_icons_enabled"`
+
+	// Marker files checked in order to detect the project type (empty = built-in list)
+	ProjectTypes []project.Marker `yaml:"project_types,omitempty"`
+
+	// Show runtime versions pinned in the session directory (.nvmrc, .python-version, ...)
+	// EnvFiles overrides which files are read (empty = built-in list)
+	EnvVersionsEnabled bool     `yaml:"env_versions_enabled"`
+	EnvFiles           []string `yaml:"env_files"`
+
+	// Show the active pane's current command and title after each session
+	// (shells and editors often set the title to what is running)
+	PaneTitleEnabled bool `yaml:"pane_title_enabled"`
+
+	// Blank lines between sessions (with their expanded windows) in the list: 0 or 1
+	RowSpacing int `yaml:"row_spacing"`
+
+	// Ask before quitting while sessions have uncommitted changes or run watched commands
+	WarnOnQuit bool `yaml:"warn_on_quit"`
+
+	// Sessions with a pane running one of these commands (e.g. npm, cargo) get WatchGlyph after their name
+	WatchCommands []string `yaml:"watch_commands"`
+	WatchGlyph    string   `yaml:"watch_glyph"`
+
+	// Expand all sessions (showing their windows) when helm opens.
+	// With single_expand, expanding a session afterwards collapses the others.
+	ExpandOnStart bool `yaml:"expand_on_start"`
+
+	// Collapse other sessions (and windows) when expanding one (default: true)
+	SingleExpand bool `yaml:"single_expand"`
+
+	// Selecting a session row lands on its last (previously active) window
+	SwitchToLastWindow bool `yaml:"switch_to_last_window"`
+
+	// Order of windows in an expanded session: "index" (tmux's order, default) or "activity"
+	WindowSort string `yaml:"window_sort"`
+
+	// Command copied by C-y for the selected item: "tmux" (tmux switch-client -t ..., default) or "helm" (helm switch ...)
+	YankCommand string `yaml:"yank_command"`
+
+	// How number keys jump to sessions: "" (bare digits while no filter is typed, default),
+	// "alt" (M-<n>) or a single key pressed before the digit (e.g. "g", then 2)
+	// With a prefix, digits always go to the filter, so numeric names can be searched
+	JumpPrefix string `yaml:"jump_prefix"`
+
+	// Show the current session (dimmed, not selectable) at the top of the list
+	ShowCurrent bool `yaml:"show_current"`
+
+	// List the current session as a normal row (takes precedence over show_current)
+	// Selecting it just closes helm, so helm can stand in for choose-tree
+	IncludeCurrent bool `yaml:"include_current"`
+
+	// Show up to N recently opened projects without a session below the sessions (0 = off)
+	RecentProjects int `yaml:"recent_projects"`
+
+	// Show only the N most recently active sessions while not filtering (0 = unlimited)
+	// The filter still searches all sessions
+	MaxSessions int `yaml:"max_sessions"`
+
+	// Hide sessions without activity for this long while not filtering (e.g. 72h; unset = show all)
+	// F3 shows them anyway; the filter still searches all sessions
+	ActiveWithin string `yaml:"active_within"`
+
+	// Start in compact mode (one "N name" line per session, no columns)
+	Compact bool `yaml:"compact"`
+
+	// Seconds between automatic session list reloads (0 = disabled)
+	RefreshInterval int `yaml:"refresh_interval"`
+
+	// Switch as soon as the filter narrows the list to a single session (after a short typing pause)
+	AutoSwitchOnUnique bool `yaml:"auto_switch_on_unique"`
+
+	// Quit when no key was pressed for this long (e.g. "10m"; empty = never)
+	AutoQuitAfter string `yaml:"auto_quit_after"`
+
+	// App width bounds in columns (0 = no limit). On wider terminals the app is centered.
+	MinWidth int `yaml:"min_width"`
+	MaxWidth int `yaml:"max_width"`
+
 	// Directory for status cache files
 	CacheDir string `yaml:"cache_dir"`
 
+	// Append tmux calls, mode changes and load timings to cache_dir/debug.log
+	Debug bool `yaml:"debug"`
+
 	// Base directories for project picker (C-p) - supports multiple paths
 	ProjectDirs []string `yaml:"project_dirs"`
 
 	// Scan depth for project directories (default: 2 for owner/repo structure)
 	ProjectDepth int `yaml:"project_depth"`
 
+	// Dot-directories are skipped while scanning project_dirs; IncludeHidden lists
+	// them all (except .git), HiddenDirs only the named ones (e.g. .work)
+	IncludeHidden bool     `yaml:"include_hidden"`
+	HiddenDirs    []string `yaml:"hidden_dirs"`
+
+	// How picker entries are shown and filtered: depth (last project_depth
+	// components), relative (path below its project_dirs root) or full
+	PickerDisplay string `yaml:"picker_display"`
+
+	// What session rows show: tmux (the sanitized session name, default) or path
+	// (the session's directory, rendered like picker_display); switching still uses the tmux name
+	NameDisplay string `yaml:"name_display"`
+
 	// Default directory for new sessions created with C-n
 	DefaultSessionDir string `yaml:"default_session_dir"`
 
-	// Lazygit popup dimensions
+	// Seed the C-p/C-r list filters with the session filter typed so far
+	CarryFilter bool `yaml:"carry_filter"`
+
+	// Pre-fills the C-n name input; supports {date}, {time} and {n} (first free number)
+	NewSessionTemplate string `yaml:"new_session_template"`
+
+	// Fetch description and language for the clone list (C-r); slower for large listings
+	CloneDetailsEnabled bool `yaml:"clone_details_enabled"`
+
+	// Lazygit popup dimensions, also used by the other popups (file manager, GitHub lists)
 	LazygitPopup PopupConfig `yaml:"lazygit_popup"`
 
+	// File manager opened in a popup in the selected session's directory (C-w)
+	FileManagerCmd string `yaml:"file_manager_cmd"`
+
+	// Turn off every popup helm launches (lazygit, file manager, GitHub lists, config
+	// editor) for locked-down setups; switching, creating and killing still work
+	DisablePopups bool `yaml:"disable_popups"`
+
+	// Key and size of the helm popup binding printed by helm tmux-bindings
+	HelmPopup PopupBindingConfig `yaml:"helm_popup"`
+
 	// Quick-access session bookmarks (slots 1-9, maps to M-1 through M-9)
 	Bookmarks []Bookmark `yaml:"bookmarks,omitempty"`
 
 	// Repositories to ensure are cloned (used by helm setup)
 	EnsureCloned []EnsureClonedEntry `yaml:"ensure_cloned,omitempty"`
+
+	// Declarative session templates (used by helm new --template)
+	Templates []Template `yaml:"templates,omitempty"`
 }
 
 // PopupConfig holds popup dimension settings
@@ -51,6 +198,48 @@ type PopupConfig struct {
 	Height string `yaml:"height"`
 }
 
+// PopupBindingConfig is a popup opened by a root-table tmux key (no prefix)
+type PopupBindingConfig struct {
+	Key    string `yaml:"key"` // tmux key name, e.g. M-w ("" = no binding)
+	Width  string `yaml:"width"`
+	Height string `yaml:"height"`
+}
+
+// Template is a named set of windows to create a session with
+type Template struct {
+	Name    string           `yaml:"name"`
+	Windows []TemplateWindow `yaml:"windows"`
+}
+
+// TemplateWindow is a window in a session template
+type TemplateWindow struct {
+	Name    string `yaml:"name"`
+	Command string `yaml:"command,omitempty"` // Typed into the window after creation
+	Dir     string `yaml:"dir,omitempty"`     // Relative to the session directory, or absolute/~
+}
+
+// ResolveDir returns the window's working directory for a session rooted at base
+func (w TemplateWindow) ResolveDir(base string) string {
+	if w.Dir == "" {
+		return base
+	}
+	dir := expandPath(w.Dir)
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(base, dir)
+}
+
+// FindTemplate returns the template with the given name
+func (c Config) FindTemplate(name string) (Template, bool) {
+	for _, t := range c.Templates {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
+
 // Bookmark represents a quick-access session bookmark
 type Bookmark struct {
 	Path string `yaml:"path"`
@@ -85,23 +274,152 @@ func DefaultConfig() Config {
 		ProjectDirs:         []string{filepath.Join(home, "repos")},
 		ProjectDepth:        2,
 		DefaultSessionDir:   home,
+		SingleExpand:        true,
+		WatchGlyph:          "◆",
+		FileManagerCmd:      "yazi",
 		LazygitPopup: PopupConfig{
 			Width:  "90%",
 			Height: "90%",
 		},
+		HelmPopup: PopupBindingConfig{
+			Key:    "M-w",
+			Width:  "50%",
+			Height: "35%",
+		},
 	}
 }
 
+// pathOverride replaces the default config file path (helm --config)
+var pathOverride string
+
+// SetPath makes Path return path instead of ~/.config/helm/config.yml
+// The bookmarks and settings files move along, next to the config file
+func SetPath(path string) {
+	path = expandPath(path)
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	pathOverride = path
+}
+
 // Path returns the path to the config file
 func Path() string {
+	if pathOverride != "" {
+		return pathOverride
+	}
 	home := os.Getenv("HOME")
 	return filepath.Join(home, ".config", "helm", "config.yml")
 }
 
+// Command returns the shell command that starts helm with the same config file,
+// for tmux bindings and popups that reopen helm
+func Command() string {
+	if pathOverride == "" {
+		return "helm"
+	}
+	return "helm --config " + shell.Quote(pathOverride)
+}
+
+// ScanHidden reports whether the project scan descends into the dot-directory name
+func (c Config) ScanHidden(name string) bool {
+	if c.IncludeHidden {
+		return name != ".git"
+	}
+	for _, dir := range c.HiddenDirs {
+		if dir == name {
+			return true
+		}
+	}
+	return false
+}
+
+// window_sort values
+const (
+	WindowSortIndex    = "index"
+	WindowSortActivity = "activity"
+)
+
+// picker_display values
+const (
+	PickerDisplayDepth    = "depth"
+	PickerDisplayRelative = "relative"
+	PickerDisplayFull     = "full"
+)
+
+// name_display values
+const (
+	NameDisplayTmux = "tmux"
+	NameDisplayPath = "path"
+)
+
+// yank_command values
+const (
+	YankCommandTmux = "tmux"
+	YankCommandHelm = "helm"
+)
+
+// jump_prefix value for M-<n> jumps (any other non-empty value is a prefix key)
+const JumpPrefixAlt = "alt"
+
+// DefaultLayoutTimeout is used when layout_timeout is unset or invalid
+const DefaultLayoutTimeout = 10 * time.Second
+
+// LayoutTimeoutDuration returns layout_timeout, or DefaultLayoutTimeout if unset or invalid
+func (c Config) LayoutTimeoutDuration() time.Duration {
+	if d := positiveDuration(c.LayoutTimeout); d > 0 {
+		return d
+	}
+	return DefaultLayoutTimeout
+}
+
+// ClaudeStaleDuration returns claude_stale_threshold, or 0 (use the default) if unset or invalid
+func (c Config) ClaudeStaleDuration() time.Duration {
+	return positiveDuration(c.ClaudeStaleThreshold)
+}
+
+// ClaudeWaitDuration returns claude_wait_threshold, or 0 (use the default) if unset or invalid
+func (c Config) ClaudeWaitDuration() time.Duration {
+	return positiveDuration(c.ClaudeWaitThreshold)
+}
+
+// positiveDuration parses s, returning 0 if it is empty, invalid or not positive
+func positiveDuration(s string) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return d
+}
+
+// ActiveWithinDuration returns active_within, or 0 (show every session) if unset or invalid
+func (c Config) ActiveWithinDuration() time.Duration {
+	return positiveDuration(c.ActiveWithin)
+}
+
+// AutoQuitDuration returns auto_quit_after, or 0 (never) if unset or invalid
+func (c Config) AutoQuitDuration() time.Duration {
+	return positiveDuration(c.AutoQuitAfter)
+}
+
+// Exists reports whether the config file is present
+func Exists() bool {
+	_, err := os.Stat(Path())
+	return err == nil
+}
+
+// Editor returns the command to edit the config file with: $VISUAL, then $EDITOR
+func Editor() (string, error) {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor, nil
+		}
+	}
+	return "", errors.New("$EDITOR is not set")
+}
+
 // BookmarksPath returns the path to the separate bookmarks file
 func BookmarksPath() string {
-	home := os.Getenv("HOME")
-	return filepath.Join(home, ".config", "helm", "bookmarks.yml")
+	return filepath.Join(filepath.Dir(Path()), "bookmarks.yml")
 }
 
 // BookmarksFile represents the structure of the bookmarks file
@@ -110,7 +428,7 @@ type BookmarksFile struct {
 }
 
 // Load reads configuration from file and environment variables.
-// Priority: env vars > config file > defaults
+// Priority: env vars > settings view overrides > config file > defaults
 func Load() (Config, error) {
 	cfg := DefaultConfig()
 
@@ -122,13 +440,46 @@ func Load() (Config, error) {
 			return cfg, fmt.Errorf("failed to read config file: %w", err)
 		}
 		if err := yaml.Unmarshal(data, &cfg); err != nil {
-			return cfg, fmt.Errorf("failed to parse config file: %w", err)
+			return cfg, &ValidationError{Path: configPath, Problems: parseProblems(err, false)}
+		}
+		if problems := errorsOnly(validate(data, cfg)); len(problems) > 0 {
+			return cfg, &ValidationError{Path: configPath, Problems: problems}
 		}
 	}
 
+	// Changes made in the settings view (C-s) override config.yml
+	if err := applySettingsFile(&cfg); err != nil {
+		return cfg, err
+	}
+
+	// Legacy environment variables override config file
+	if val := os.Getenv("TMUX_LAYOUT"); val != "" {
+		cfg.Layout = val
+	}
+	if val := os.Getenv("TMUX_LAYOUTS_DIR"); val != "" {
+		cfg.LayoutDir = expandPath(val)
+	}
+	if os.Getenv("TMUX_SESSION_PICKER_CLAUDE_STATUS") == "1" {
+		cfg.ClaudeStatusEnabled = true
+	}
+	if os.Getenv("TMUX_SESSION_PICKER_GIT_STATUS") == "1" {
+		cfg.GitStatusEnabled = true
+	}
+
+	// HELM_<KEY> environment variables override any scalar config field
+	// The overridden fields are validated like the config file's
+	overridden, err := applyEnvOverrides(&cfg)
+	if err != nil {
+		return cfg, err
+	}
+	if problems := errorsOnly(validateFields(overridden, cfg)); len(problems) > 0 {
+		return cfg, &ValidationError{Path: EnvSource, Problems: problems}
+	}
+
 	// Expand ~ in paths
 	cfg.LayoutDir = expandPath(cfg.LayoutDir)
 	cfg.CacheDir = expandPath(cfg.CacheDir)
+	cfg.Socket = expandPath(cfg.Socket)
 	cfg.DefaultSessionDir = expandPath(cfg.DefaultSessionDir)
 
 	// Expand ~ in project directories
@@ -146,18 +497,14 @@ func Load() (Config, error) {
 		cfg.ProjectDepth = 2
 	}
 
-	// Environment variables override config file
-	if val := os.Getenv("TMUX_LAYOUT"); val != "" {
-		cfg.Layout = val
-	}
-	if val := os.Getenv("TMUX_LAYOUTS_DIR"); val != "" {
-		cfg.LayoutDir = expandPath(val)
-	}
-	if os.Getenv("TMUX_SESSION_PICKER_CLAUDE_STATUS") == "1" {
-		cfg.ClaudeStatusEnabled = true
+	// Negative refresh interval means disabled
+	if cfg.RefreshInterval < 0 {
+		cfg.RefreshInterval = 0
 	}
-	if os.Getenv("TMUX_SESSION_PICKER_GIT_STATUS") == "1" {
-		cfg.GitStatusEnabled = true
+
+	// A max_width below min_width cannot be satisfied; min wins
+	if cfg.MaxWidth > 0 && cfg.MaxWidth < cfg.MinWidth {
+		cfg.MaxWidth = cfg.MinWidth
 	}
 
 	// Load bookmarks from separate file (takes priority over config.yml bookmarks)
@@ -211,7 +558,7 @@ func Init() error {
 
 	// Write default config with comments
 	content := `# helm configuration
-# Environment variables override these settings
+# Environment variables override these settings: HELM_<KEY>, e.g. HELM_PROJECT_DEPTH=3
 
 # Layout script name to apply when creating new sessions
 # layout: ide
@@ -219,17 +566,129 @@ func Init() error {
 # Directory containing layout scripts
 # layout_dir: ~/.config/tmux/layouts
 
+# Kill the layout script if it hangs for longer than this (the session is still switched to)
+# layout_timeout: 10s
+
+# Shell or command new sessions start with instead of tmux's default-shell
+# session_shell: fish
+
+# tmux server to manage: a socket name (like tmux -L work) or a path (like tmux -S /tmp/work.sock)
+# Unset uses the server helm runs in. Also HELM_SOCKET=work
+# socket: work
+
 # Enable Claude Code status integration
 # claude_status_enabled: false
 
+# Status file format: "colon" (state:timestamp, default) or "json" ({"state":"working","ts":...})
+# claude_status_format: colon
+
+# Durations: a "working" status older than the stale threshold is ignored,
+# "waiting" escalates from ? to ! after the wait threshold
+# claude_stale_threshold: 2m
+# claude_wait_threshold: 5m
+
 # Enable git status indicator (shows dirty/ahead/behind for repos)
 # git_status_enabled: false
 
+# Show a colored activity dot before the time column (green <5m, yellow <1h, dim older)
+# activity_indicator_enabled: false
+
+# Show an AGE column with how long ago each session was created
+# session_age_enabled: false
+
+# Show a project type icon before each session name, detected from marker files
+# project_icons_enabled: false
+# Override the built-in markers (checked in order, first match wins):
+# project_types:
+#   - { file: go.mod, type: go, icon: go }
+#   - { file: package.json, type: js, icon: js }
+
+# Mark sessions where any pane runs one of these commands (builds, servers, watchers)
+# watch_commands: [npm, cargo, air]
+# watch_glyph: ◆
+
+# Show runtime versions pinned in each session's directory (node@20 python@3.12)
+# env_versions_enabled: false
+# Files to read (default: .nvmrc, .node-version, .python-version, .ruby-version, .tool-versions)
+# env_files: [.nvmrc, .tool-versions]
+
+# Show each session's active pane command and title (nvim · notes.md)
+# pane_title_enabled: false
+
+# Blank line between sessions (each with its expanded windows) for readability: 0 or 1
+# row_spacing: 0
+
+# Ask before quitting while sessions have uncommitted changes (needs git_status_enabled)
+# or are running watch_commands
+# warn_on_quit: false
+
+# Expand all sessions on open to show their windows
+# (with single_expand, expanding a session afterwards collapses the others)
+# expand_on_start: false
+
+# Collapse other sessions/windows when expanding one; false lets expansions accumulate
+# single_expand: true
+
+# Selecting a session lands on its last (previously active) window
+# instead of the window that is currently active in it
+# switch_to_last_window: false
+
+# Order of windows in an expanded session: index (like tmux) or activity (most recent first)
+# window_sort: index
+
+# Command C-y copies to reach the selected session/window:
+# tmux (tmux switch-client -t <target>) or helm (helm switch <target>)
+# yank_command: tmux
+
+# Number keys jump to sessions while no filter is typed; with a prefix, digits
+# always filter (e.g. a session named 2024) and jumps take alt (M-2) or a key first (g 2)
+# jump_prefix: alt
+
+# Show the current session dimmed at the top of the list (not selectable)
+# show_current: false
+
+# List the current session as a normal, selectable row (like tmux's choose-tree)
+# Selecting it just closes helm; takes precedence over show_current
+# include_current: false
+
+# Show up to N recently opened projects (from C-p) that have no session, below the sessions
+# Their numbers continue after the sessions; selecting one creates the session
+# recent_projects: 0
+
+# Show only the N most recently active sessions until you type a filter (0 = all)
+# The filter still searches every session
+# max_sessions: 0
+
+# Hide sessions untouched for longer than this until you filter or press F3 (unset = show all)
+# active_within: 168h
+
+# Compact session list: just "N name" per row, no time/git/claude columns (toggle with C-t)
+# compact: false
+
+# Reload the session list every N seconds (0 = disabled, F5 refreshes manually)
+# refresh_interval: 0
+
+# Switch automatically once the filter matches exactly one session
+# (waits for a short pause in typing so it doesn't fire mid-word)
+# auto_switch_on_unique: false
+
+# Quit after this long without a key press, so a forgotten popup closes itself
+# auto_quit_after: 10m
+
+# App width bounds in columns (0 = no limit); centered when the terminal is wider
+# min_width: 0
+# max_width: 0
+
 # Directory for status cache files
 # cache_dir: ~/.cache/helm
 
+# Log tmux calls, mode changes and load timings to cache_dir/debug.log
+# (also HELM_DEBUG=1)
+# debug: false
+
 # Base directories for project picker (C-p)
 # Supports multiple paths - all will be scanned
+# ~ and environment variables are expanded, e.g. $WORK_ROOT/repos
 # project_dirs:
 #   - ~/repos
 # Example with multiple paths:
@@ -241,19 +700,65 @@ func Init() error {
 # Scan depth for project directories (2 = owner/repo structure)
 # project_depth: 2
 
+# Dot-directories are skipped while scanning; list them all (except .git) or only some
+# include_hidden: false
+# hidden_dirs: [.work, .dotfiles]
+
+# How the directory picker shows entries (filtering matches what is shown):
+# depth (last project_depth components), relative (below its project_dirs root) or full
+# picker_display: depth
+
+# What session rows show: tmux (the session name, with / and . turned into -) or
+# path (the session's directory, shown like picker_display, e.g. nikbrunner/nbr.haus)
+# name_display: tmux
+
 # Default directory for new sessions created with C-n
 # default_session_dir: ~
 
-# Lazygit popup dimensions (C-g)
+# Keep the typed session filter when opening the directory picker (C-p) or clone list (C-r)
+# carry_filter: false
+
+# Pre-fill the C-n name input (accept with Enter or edit it); empty = blank input
+# {date} = 2006-01-02, {time} = 1504, {n} = lowest number not taken by a session
+# new_session_template: scratch-{n}
+
+# Show repo language and description in the clone list (C-r); slower for large listings
+# clone_details_enabled: false
+
+# Lazygit popup dimensions (C-g), also used by the file manager and GitHub popups
 # lazygit_popup:
 #   width: 90%
 #   height: 90%
 
+# File manager opened in the selected session's directory (C-w): yazi, ranger, lf, ...
+# file_manager_cmd: yazi
+
+# Never launch popups or external commands (C-g, C-w, C-e/C-u, C-e in settings)
+# disable_popups: false
+
+# Binding that opens helm in a popup, printed by 'helm tmux-bindings' (key "" to skip)
+# helm_popup:
+#   key: M-w
+#   width: 50%
+#   height: 35%
+
 # Quick-access session bookmarks (slots 1-9, maps to M-1 through M-9)
 # Use 'helm tmux-bindings' to generate tmux keybindings
 # Note: Bookmarks are stored separately in ~/.config/helm/bookmarks.yml
 # to preserve comments in this file when bookmarks are modified via the TUI.
 
+# Session templates: named window sets for helm new --template <name>
+# Window dirs are relative to the session directory unless absolute
+# templates:
+#   - name: dev
+#     windows:
+#       - name: editor
+#         command: nvim
+#       - name: server
+#         command: npm run dev
+#       - name: docs
+#         dir: docs
+
 # Repositories to ensure are cloned (used by 'helm setup')
 # Supports plain URLs and objects with post_clone hooks.
 # Wildcard patterns (org/*) expand via gh CLI.
@@ -292,13 +797,15 @@ func (cfg *Config) SaveBookmarks() error {
 	return nil
 }
 
-// expandPath expands ~ to the user's home directory
+// expandPath expands ~ to the user's home directory and $VAR / ${VAR} to environment
+// variables (unset ones expand to ""), e.g. "$WORK_ROOT/repos"
+// The rest of the path is expanded before joining, so a $ in $HOME is left alone
 func expandPath(path string) string {
 	if len(path) > 0 && path[0] == '~' {
 		home := os.Getenv("HOME")
-		return filepath.Join(home, path[1:])
+		return filepath.Join(home, os.ExpandEnv(path[1:]))
 	}
-	return path
+	return os.ExpandEnv(path)
 }
 
 // RepoInfo represents a cloned repository with its location
@@ -1,13 +1,19 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestExpandPath(t *testing.T) {
 	home := os.Getenv("HOME")
+	t.Setenv("WORK_ROOT", "/srv/work")
+	t.Setenv("HELM_TEST_UNSET", "")
 
 	tests := []struct {
 		name     string
@@ -39,6 +45,26 @@ func TestExpandPath(t *testing.T) {
 			input:    "~",
 			expected: home,
 		},
+		{
+			name:     "expands environment variables",
+			input:    "$WORK_ROOT/repos",
+			expected: "/srv/work/repos",
+		},
+		{
+			name:     "expands braced variables",
+			input:    "${WORK_ROOT}-old/repos",
+			expected: "/srv/work-old/repos",
+		},
+		{
+			name:     "expands variables after tilde",
+			input:    "~/$WORK_ROOT/repos",
+			expected: filepath.Join(home, "srv/work/repos"),
+		},
+		{
+			name:     "drops unset variables",
+			input:    "~/code/$HELM_TEST_UNSET/x",
+			expected: filepath.Join(home, "code/x"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -66,6 +92,14 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.Layout != "" {
 		t.Errorf("Layout = %q, want empty string", cfg.Layout)
 	}
+
+	if !cfg.SingleExpand {
+		t.Error("SingleExpand should be true by default")
+	}
+
+	if cfg.HelmPopup.Key != "M-w" || !validPopupSize(cfg.HelmPopup.Width) || !validPopupSize(cfg.HelmPopup.Height) {
+		t.Errorf("HelmPopup = %+v, want key M-w with valid sizes", cfg.HelmPopup)
+	}
 }
 
 func TestPath(t *testing.T) {
@@ -186,3 +220,274 @@ bookmarks:
 		t.Errorf("Bookmark path = %q, want %q (bookmarks.yml should take priority)", cfg.Bookmarks[0].Path, "/from/bookmarks")
 	}
 }
+
+func TestCheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".config", "helm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	configContent := `layout: test
+project_depth: 0
+lazygit_popup:
+  width: wide
+  height: 90%
+unknown_key: true
+jump_prefix: "1"
+`
+	if err := os.WriteFile(Path(), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := Check()
+	if err != nil || len(reports) != 1 || reports[0].Path != Path() {
+		t.Fatalf("Check() = %v, %v: want one report for config.yml", reports, err)
+	}
+	problems := reports[0].Problems
+
+	want := map[string]Problem{
+		"project_depth":       {Line: 2, Field: "project_depth", Warning: true},
+		"lazygit_popup.width": {Line: 3, Field: "lazygit_popup.width"},
+		"":                    {Line: 6, Warning: true},
+		"jump_prefix":         {Line: 7},
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("Check() returned %d problems, want %d: %v", len(problems), len(want), problems)
+	}
+	for _, p := range problems {
+		w, ok := want[p.Field]
+		if !ok {
+			t.Errorf("unexpected problem: %s", p)
+			continue
+		}
+		if p.Line != w.Line || p.Warning != w.Warning {
+			t.Errorf("problem %q: line=%d warning=%v, want line=%d warning=%v", p, p.Line, p.Warning, w.Line, w.Warning)
+		}
+	}
+
+	// Load fails on errors but not on the warnings (unknown key, clamped project_depth)
+	var verr *ValidationError
+	if _, err := Load(); !errors.As(err, &verr) || len(verr.Problems) != 2 {
+		t.Errorf("Load() error = %v, want ValidationError with 2 problems", err)
+	}
+
+	if err := os.WriteFile(Path(), []byte("project_depth: 0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err := Load(); err != nil || cfg.ProjectDepth != 2 {
+		t.Errorf("Load() = depth %d, %v: want project_depth clamped to 2 without failing", cfg.ProjectDepth, err)
+	}
+}
+
+func TestClaudeDurations(t *testing.T) {
+	cfg := Config{ClaudeStaleThreshold: "90s", ClaudeWaitThreshold: "soon"}
+	if got := cfg.ClaudeStaleDuration(); got != 90*time.Second {
+		t.Errorf("ClaudeStaleDuration() = %v, want 90s", got)
+	}
+	if got := cfg.ClaudeWaitDuration(); got != 0 {
+		t.Errorf("ClaudeWaitDuration() with invalid value = %v, want 0 (default)", got)
+	}
+
+	problems := validate([]byte("claude_wait_threshold: soon\n"), cfg)
+	if len(problems) != 1 || problems[0].Field != "claude_wait_threshold" || problems[0].Warning {
+		t.Errorf("validate() = %v, want one claude_wait_threshold error", problems)
+	}
+}
+
+func TestLayoutTimeoutDuration(t *testing.T) {
+	if got := (Config{}).LayoutTimeoutDuration(); got != DefaultLayoutTimeout {
+		t.Errorf("LayoutTimeoutDuration() unset = %v, want %v", got, DefaultLayoutTimeout)
+	}
+	if got := (Config{LayoutTimeout: "30s"}).LayoutTimeoutDuration(); got != 30*time.Second {
+		t.Errorf("LayoutTimeoutDuration() = %v, want 30s", got)
+	}
+}
+
+func TestSaveSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".config", "helm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	configContent := "# my comment\ngit_status_enabled: false\nproject_depth: 2\n"
+	if err := os.WriteFile(Path(), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.SetSetting("no_such_setting", "1"); err == nil {
+		t.Error("SetSetting() with unknown key should fail")
+	}
+	for key, value := range map[string]string{"git_status_enabled": "true", "project_depth": "3"} {
+		if err := SaveSetting(key, value); err != nil {
+			t.Fatalf("SaveSetting(%s) error: %v", key, err)
+		}
+	}
+	// Only the saved keys are written, never the rest of the running config
+	if overrides, err := readSettingsFile(); err != nil || len(overrides) != 2 {
+		t.Errorf("settings file = %v (%v), want only the 2 saved keys", overrides, err)
+	}
+
+	// config.yml is left untouched; the overrides apply on the next Load
+	if data, _ := os.ReadFile(Path()); string(data) != configContent {
+		t.Errorf("config.yml was modified:\n%s", data)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.GitStatusEnabled || loaded.ProjectDepth != 3 {
+		t.Errorf("Load() after Save: git_status_enabled=%v project_depth=%d, want true and 3", loaded.GitStatusEnabled, loaded.ProjectDepth)
+	}
+
+	// Reverting every change removes the overrides file
+	for key, value := range map[string]string{"git_status_enabled": "false", "project_depth": "2"} {
+		if err := SaveSetting(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(SettingsPath()); !os.IsNotExist(err) {
+		t.Error("settings file should be removed when nothing differs from config.yml")
+	}
+
+	// Ints that look like bools keep their type
+	for _, value := range []string{"0", "1"} {
+		if err := SaveSetting("max_sessions", value); err != nil {
+			t.Fatal(err)
+		}
+		if loaded, err := Load(); err != nil || strconv.Itoa(loaded.MaxSessions) != value {
+			t.Errorf("Load() after saving max_sessions=%s: %d, %v", value, loaded.MaxSessions, err)
+		}
+	}
+
+	// A hand-edited settings file is validated like config.yml
+	if err := os.WriteFile(SettingsPath(), []byte("max_sessions: -1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var verr *ValidationError
+	if _, err := Load(); !errors.As(err, &verr) || verr.Path != SettingsPath() {
+		t.Errorf("Load() error = %v, want a ValidationError for settings.yml", err)
+	}
+
+	// helm check reports it too, after config.yml
+	reports, err := Check()
+	if err != nil || len(reports) != 2 || reports[1].Path != SettingsPath() || len(errorsOnly(reports[1].Problems)) != 1 {
+		t.Errorf("Check() = %v, %v: want a settings.yml report with one error", reports, err)
+	}
+}
+
+func TestEnvOverrides(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("HELM_PROJECT_DEPTH", "3")
+	t.Setenv("HELM_GIT_STATUS_ENABLED", "true")
+	t.Setenv("HELM_CACHE_DIR", "~/cache")
+	t.Setenv("HELM_LAZYGIT_POPUP_WIDTH", "80%")
+	t.Setenv("HELM_PROJECT_DIRS", "/a:/b")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	if cfg.ProjectDepth != 3 {
+		t.Errorf("ProjectDepth = %d, want 3", cfg.ProjectDepth)
+	}
+	if !cfg.GitStatusEnabled {
+		t.Error("GitStatusEnabled should be set from HELM_GIT_STATUS_ENABLED")
+	}
+	if want := expandPath("~/cache"); cfg.CacheDir != want {
+		t.Errorf("CacheDir = %q, want %q", cfg.CacheDir, want)
+	}
+	if cfg.LazygitPopup.Width != "80%" {
+		t.Errorf("LazygitPopup.Width = %q, want %q", cfg.LazygitPopup.Width, "80%")
+	}
+	if len(cfg.ProjectDirs) != 2 || cfg.ProjectDirs[1] != "/b" {
+		t.Errorf("ProjectDirs = %v, want [/a /b]", cfg.ProjectDirs)
+	}
+
+	t.Setenv("HELM_PROJECT_DEPTH", "deep")
+	if _, err := Load(); err == nil {
+		t.Error("Load() should fail on a non-integer HELM_PROJECT_DEPTH")
+	}
+	t.Setenv("HELM_PROJECT_DEPTH", "3")
+
+	t.Setenv("HELM_BOOKMARKS", "/a")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "HELM_BOOKMARKS") {
+		t.Errorf("Load() error = %v, want HELM_BOOKMARKS reported as unsupported", err)
+	}
+	os.Unsetenv("HELM_BOOKMARKS")
+
+	t.Setenv("HELM_WINDOW_SORT", "random")
+	var verr *ValidationError
+	if _, err := Load(); !errors.As(err, &verr) || verr.Path != EnvSource || verr.Problems[0].Field != "window_sort" {
+		t.Errorf("Load() error = %v, want the overridden window_sort validated", err)
+	}
+}
+
+func TestTemplateWindowResolveDir(t *testing.T) {
+	home := os.Getenv("HOME")
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{dir: "", want: "/work/app"},
+		{dir: "docs", want: "/work/app/docs"},
+		{dir: "/tmp", want: "/tmp"},
+		{dir: "~/notes", want: filepath.Join(home, "notes")},
+	}
+
+	for _, tt := range tests {
+		w := TemplateWindow{Name: "w", Dir: tt.dir}
+		if got := w.ResolveDir("/work/app"); got != tt.want {
+			t.Errorf("ResolveDir(%q) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTemplates(t *testing.T) {
+	templates := []Template{
+		{Name: "dev", Windows: []TemplateWindow{{Name: "editor"}}},
+		{Name: "dev", Windows: []TemplateWindow{{Name: ""}}},
+		{Name: "", Windows: nil},
+	}
+
+	problems := validateTemplates(templates, 1)
+	// duplicate name, unnamed window, missing name, no windows
+	if len(problems) != 4 {
+		t.Errorf("validateTemplates() returned %d problems, want 4: %v", len(problems), problems)
+	}
+}
+
+func TestSetPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { pathOverride = "" })
+
+	path := filepath.Join(tmpDir, "bob's work.yml")
+	if err := os.WriteFile(path, []byte("project_depth: 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	SetPath(path)
+
+	if BookmarksPath() != filepath.Join(tmpDir, "bookmarks.yml") || SettingsPath() != filepath.Join(tmpDir, "settings.yml") {
+		t.Errorf("bookmarks/settings = %s, %s, want them next to %s", BookmarksPath(), SettingsPath(), path)
+	}
+	if want := "helm --config '" + tmpDir + "/bob'\\''s work.yml'"; Command() != want {
+		t.Errorf("Command() = %q, want %q", Command(), want)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ProjectDepth != 3 {
+		t.Errorf("ProjectDepth = %d, want 3 from %s", cfg.ProjectDepth, path)
+	}
+}
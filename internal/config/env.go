@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix is prepended to the upper-cased YAML key to form override variables,
+// e.g. project_depth -> HELM_PROJECT_DEPTH, lazygit_popup.width -> HELM_LAZYGIT_POPUP_WIDTH
+const EnvPrefix = "HELM_"
+
+// EnvSource names where HELM_<KEY> overrides come from in a ValidationError
+const EnvSource = "environment (HELM_*)"
+
+// applyEnvOverrides sets config fields from HELM_<KEY> environment variables
+// Covers string, bool and int fields (recursing into nested structs) and
+// string lists, which are split on the OS path list separator (":" on Unix)
+// Returns the top-level keys it set, as lines for validateFields (all 0)
+func applyEnvOverrides(cfg *Config) (map[string]int, error) {
+	keys := make(map[string]int)
+	err := applyEnvToStruct(reflect.ValueOf(cfg).Elem(), EnvPrefix, func(key string) { keys[key] = 0 })
+	return keys, err
+}
+
+// applyEnvToStruct sets the fields of v from prefix+KEY variables, calling set
+// with the YAML key of each field it sets (of the struct for nested fields)
+func applyEnvToStruct(v reflect.Value, prefix string, set func(key string)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		name := prefix + strings.ToUpper(key)
+		field := v.Field(i)
+
+		if field.Kind() == reflect.Struct {
+			if err := applyEnvToStruct(field, name+"_", func(string) { set(key) }); err != nil {
+				return err
+			}
+			continue
+		}
+
+		val, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setFromEnv(field, val); err != nil {
+			return fmt.Errorf("invalid %s=%q: %w", name, val, err)
+		}
+		set(key)
+	}
+	return nil
+}
+
+// setFromEnv converts val to the field's type and assigns it
+// Fields of other types (e.g. bookmark lists) can only be set in config.yml
+func setFromEnv(field reflect.Value, val string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("expected true/false or 1/0")
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("expected an integer")
+		}
+		field.SetInt(int64(n))
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("not supported; set it in config.yml")
+		}
+		var items []string
+		for _, item := range filepath.SplitList(val) {
+			if item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("not supported; set it in config.yml")
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Setting is a config option editable from the settings view (C-s)
+type Setting struct {
+	Key         string // YAML key
+	Description string
+	Min         int // Lower bound for int settings
+}
+
+// Settings lists the options offered in the settings view, in display order
+var Settings = []Setting{
+	{Key: "git_status_enabled", Description: "Git status column"},
+	{Key: "claude_status_enabled", Description: "Claude Code status icon"},
+	{Key: "activity_indicator_enabled", Description: "Activity dot before the time column"},
+	{Key: "session_age_enabled", Description: "AGE column"},
+	{Key: "project_icons_enabled", Description: "Project type icons"},
+	{Key: "env_versions_enabled", Description: "Pinned runtime versions (.nvmrc, ...)"},
+	{Key: "pane_title_enabled", Description: "Active pane command and title"},
+	{Key: "compact", Description: "Compact rows on start"},
+	{Key: "show_current", Description: "Current session at the top"},
+	{Key: "include_current", Description: "Current session as a normal row"},
+	{Key: "switch_to_last_window", Description: "Session rows land on their last window"},
+	{Key: "warn_on_quit", Description: "Ask before quitting with dirty or busy sessions"},
+	{Key: "expand_on_start", Description: "Expand all sessions on open"},
+	{Key: "single_expand", Description: "Expanding one session collapses the others"},
+	{Key: "carry_filter", Description: "Keep the filter when opening C-p/C-r"},
+	{Key: "clone_details_enabled", Description: "Language/description in the clone list"},
+	{Key: "project_depth", Description: "Directory depth for the project picker", Min: 1},
+	{Key: "max_sessions", Description: "Sessions shown before filtering (0 = all)"},
+}
+
+// SettingsPath returns the path to the overrides file written by the settings view
+// Kept separate from config.yml (next to it) so comments there are never rewritten
+func SettingsPath() string {
+	return filepath.Join(filepath.Dir(Path()), "settings.yml")
+}
+
+// SettingValue returns the current value of a setting as a string ("true", "2", ...)
+func (c Config) SettingValue(key string) string {
+	field, ok := fieldByKey(reflect.ValueOf(&c).Elem(), key)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprint(field.Interface())
+}
+
+// SetSetting parses value and assigns it to the setting with the given key
+func (c *Config) SetSetting(key, value string) error {
+	field, ok := fieldByKey(reflect.ValueOf(c).Elem(), key)
+	if !ok {
+		return fmt.Errorf("unknown setting: %s", key)
+	}
+	if err := setFromEnv(field, value); err != nil {
+		return fmt.Errorf("invalid %s=%q: %w", key, value, err)
+	}
+	return nil
+}
+
+// SaveSetting records a setting changed in the settings view in the overrides
+// file, which Load applies on top of config.yml. Only key is written, so
+// environment variables and run-only toggles (F6/F7) in the running config are
+// never persisted; a value equal to config.yml's drops the override.
+// Environment variables still take priority over both files
+func SaveSetting(key, value string) error {
+	base, err := loadFile()
+	if err != nil {
+		return err
+	}
+	overrides, err := readSettingsFile()
+	if err != nil {
+		return err
+	}
+
+	if value == base.SettingValue(key) {
+		delete(overrides, key)
+	} else {
+		// Parse into the field so it's written with the field's YAML type
+		// (max_sessions: 1 stays an int instead of becoming true)
+		parsed := base
+		if err := parsed.SetSetting(key, value); err != nil {
+			return err
+		}
+		field, _ := fieldByKey(reflect.ValueOf(&parsed).Elem(), key)
+		overrides[key] = field.Interface()
+	}
+
+	path := SettingsPath()
+	if len(overrides) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove settings file: %w", err)
+		}
+		return nil
+	}
+
+	data, err := yaml.Marshal(overrides)
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	content := "# Written by helm's settings view (C-s); overrides config.yml\n" + string(data)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write settings file: %w", err)
+	}
+	return nil
+}
+
+// loadFile returns the defaults with config.yml applied, without overrides or env
+func loadFile() (Config, error) {
+	cfg := DefaultConfig()
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return cfg, nil
+}
+
+// readSettingsFile returns the overrides saved by the settings view, by key
+func readSettingsFile() (map[string]any, error) {
+	overrides := make(map[string]any)
+	data, err := os.ReadFile(SettingsPath())
+	if os.IsNotExist(err) {
+		return overrides, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse settings file %s: %w", SettingsPath(), err)
+	}
+	return overrides, nil
+}
+
+// applySettingsFile overlays the settings view overrides onto cfg and validates
+// them like config.yml
+func applySettingsFile(cfg *Config) error {
+	path := SettingsPath()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read settings file: %w", err)
+	}
+	if problems := errorsOnly(settingsProblems(data, cfg)); len(problems) > 0 {
+		return &ValidationError{Path: path, Problems: problems}
+	}
+	return nil
+}
+
+// settingsProblems overlays the settings file data onto cfg and validates it
+// like config.yml, warnings included
+func settingsProblems(data []byte, cfg *Config) []Problem {
+	if problems := parseProblems(yaml.Unmarshal(data, cfg), false); len(problems) > 0 {
+		return problems
+	}
+	return validate(data, *cfg)
+}
+
+// fieldByKey finds the top-level field tagged with the given YAML key
+func fieldByKey(v reflect.Value, key string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name == key {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
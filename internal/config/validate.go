@@ -0,0 +1,389 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is a single issue found while validating the config file
+type Problem struct {
+	Line    int    // 1-based line in config.yml (0 if unknown)
+	Field   string // YAML key the problem refers to ("" if not field-specific)
+	Message string
+	Warning bool // Warnings are reported by `helm check` but don't fail Load
+}
+
+// String formats the problem as "line N: field: message"
+func (p Problem) String() string {
+	var b strings.Builder
+	if p.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", p.Line)
+	}
+	if p.Field != "" {
+		fmt.Fprintf(&b, "%s: ", p.Field)
+	}
+	b.WriteString(p.Message)
+	return b.String()
+}
+
+// ValidationError lists every error-level problem found in the config file
+type ValidationError struct {
+	Path     string
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = "  " + p.String()
+	}
+	return fmt.Sprintf("invalid config %s:\n%s", e.Path, strings.Join(lines, "\n"))
+}
+
+// Report lists the problems found in one file, warnings included
+type Report struct {
+	Path     string
+	Problems []Problem
+}
+
+// Check validates the config file and the settings view's overrides file
+// (settings.yml, checked on top of config.yml like Load does), returning a
+// report for each of them that exists
+func Check() ([]Report, error) {
+	var reports []Report
+	cfg := DefaultConfig()
+	data, err := os.ReadFile(Path())
+	switch {
+	case err == nil:
+		problems := parseProblems(yaml.Unmarshal(data, &cfg), false)
+		if len(problems) == 0 {
+			problems = validate(data, cfg)
+		}
+		reports = append(reports, Report{Path: Path(), Problems: problems})
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	data, err = os.ReadFile(SettingsPath())
+	switch {
+	case err == nil:
+		reports = append(reports, Report{Path: SettingsPath(), Problems: settingsProblems(data, &cfg)})
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("failed to read settings file: %w", err)
+	}
+	return reports, nil
+}
+
+// errorsOnly filters out warnings
+func errorsOnly(problems []Problem) []Problem {
+	var errs []Problem
+	for _, p := range problems {
+		if !p.Warning {
+			errs = append(errs, p)
+		}
+	}
+	return errs
+}
+
+// validate checks known fields of a parsed config against the raw YAML
+// data is used to find unknown keys and the line each key is defined on
+func validate(data []byte, cfg Config) []Problem {
+	var problems []Problem
+	lines := keyLines(data)
+
+	// Unknown keys are only warnings: decode again strictly and keep those errors
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var strict Config
+	for _, p := range parseProblems(dec.Decode(&strict), true) {
+		if m := unknownFieldRe.FindStringSubmatch(p.Message); m != nil {
+			p.Message = fmt.Sprintf("unknown key %q (ignored)", m[1])
+			problems = append(problems, p)
+		}
+	}
+
+	problems = append(problems, validateFields(lines, cfg)...)
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Line < problems[j].Line })
+	return problems
+}
+
+// validateFields checks the fields of cfg whose top-level keys are in lines,
+// reporting problems at the key's line (0 for keys not set in a file)
+func validateFields(lines map[string]int, cfg Config) []Problem {
+	var problems []Problem
+
+	// Load clamps project_depth, so an out-of-range value only warns
+	if line, ok := lines["project_depth"]; ok && cfg.ProjectDepth < 1 {
+		problems = append(problems, Problem{
+			Line:    line,
+			Field:   "project_depth",
+			Message: fmt.Sprintf("must be at least 1 (got %d); using 2", cfg.ProjectDepth),
+			Warning: true,
+		})
+	}
+
+	if line, ok := lines["max_sessions"]; ok && cfg.MaxSessions < 0 {
+		problems = append(problems, Problem{
+			Line:    line,
+			Field:   "max_sessions",
+			Message: fmt.Sprintf("must be 0 (unlimited) or more (got %d)", cfg.MaxSessions),
+		})
+	}
+
+	if line, ok := lines["row_spacing"]; ok && (cfg.RowSpacing < 0 || cfg.RowSpacing > 1) {
+		problems = append(problems, Problem{
+			Line:    line,
+			Field:   "row_spacing",
+			Message: fmt.Sprintf("must be 0 or 1 (got %d)", cfg.RowSpacing),
+		})
+	}
+
+	popups := []struct{ key, width, height string }{
+		{"lazygit_popup", cfg.LazygitPopup.Width, cfg.LazygitPopup.Height},
+		{"helm_popup", cfg.HelmPopup.Width, cfg.HelmPopup.Height},
+	}
+	for _, popup := range popups {
+		line, ok := lines[popup.key]
+		if !ok {
+			continue
+		}
+		sizes := []struct{ field, value string }{
+			{"width", popup.width},
+			{"height", popup.height},
+		}
+		for _, size := range sizes {
+			if !validPopupSize(size.value) {
+				problems = append(problems, Problem{
+					Line:    line,
+					Field:   popup.key + "." + size.field,
+					Message: fmt.Sprintf("%q is not a size (use cells like 80 or a percentage like 90%%)", size.value),
+				})
+			}
+		}
+	}
+
+	if line, ok := lines["project_dirs"]; ok {
+		for _, dir := range cfg.ProjectDirs {
+			if _, err := os.Stat(expandPath(dir)); err != nil {
+				problems = append(problems, Problem{
+					Line:    line,
+					Field:   "project_dirs",
+					Message: fmt.Sprintf("directory %s does not exist", dir),
+					Warning: true,
+				})
+			}
+		}
+	}
+
+	if line, ok := lines["refresh_interval"]; ok && cfg.RefreshInterval < 0 {
+		problems = append(problems, Problem{
+			Line:    line,
+			Field:   "refresh_interval",
+			Message: "negative interval disables auto-refresh; use 0",
+			Warning: true,
+		})
+	}
+
+	if line, ok := lines["claude_status_format"]; ok {
+		switch cfg.ClaudeStatusFormat {
+		case "", "colon", "json":
+		default:
+			problems = append(problems, Problem{
+				Line:    line,
+				Field:   "claude_status_format",
+				Message: fmt.Sprintf("%q is not a format (use colon or json)", cfg.ClaudeStatusFormat),
+			})
+		}
+	}
+
+	if line, ok := lines["window_sort"]; ok {
+		switch cfg.WindowSort {
+		case "", WindowSortIndex, WindowSortActivity:
+		default:
+			problems = append(problems, Problem{
+				Line:    line,
+				Field:   "window_sort",
+				Message: fmt.Sprintf("%q is not a window order (use index or activity)", cfg.WindowSort),
+			})
+		}
+	}
+
+	if line, ok := lines["picker_display"]; ok {
+		switch cfg.PickerDisplay {
+		case "", PickerDisplayDepth, PickerDisplayRelative, PickerDisplayFull:
+		default:
+			problems = append(problems, Problem{
+				Line:    line,
+				Field:   "picker_display",
+				Message: fmt.Sprintf("%q is not a display mode (use depth, relative or full)", cfg.PickerDisplay),
+			})
+		}
+	}
+
+	if line, ok := lines["name_display"]; ok {
+		switch cfg.NameDisplay {
+		case "", NameDisplayTmux, NameDisplayPath:
+		default:
+			problems = append(problems, Problem{
+				Line:    line,
+				Field:   "name_display",
+				Message: fmt.Sprintf("%q is not a display mode (use tmux or path)", cfg.NameDisplay),
+			})
+		}
+	}
+
+	if line, ok := lines["yank_command"]; ok {
+		switch cfg.YankCommand {
+		case "", YankCommandTmux, YankCommandHelm:
+		default:
+			problems = append(problems, Problem{
+				Line:    line,
+				Field:   "yank_command",
+				Message: fmt.Sprintf("%q is not a command form (use tmux or helm)", cfg.YankCommand),
+			})
+		}
+	}
+
+	if line, ok := lines["jump_prefix"]; ok && cfg.JumpPrefix != JumpPrefixAlt {
+		if r := []rune(cfg.JumpPrefix); len(r) > 1 || (len(r) == 1 && (unicode.IsDigit(r[0]) || !unicode.IsPrint(r[0]))) {
+			problems = append(problems, Problem{
+				Line:    line,
+				Field:   "jump_prefix",
+				Message: fmt.Sprintf("%q is not a prefix (use alt or a single non-digit key like g)", cfg.JumpPrefix),
+			})
+		}
+	}
+
+	durations := []struct{ field, value string }{
+		{"claude_stale_threshold", cfg.ClaudeStaleThreshold},
+		{"claude_wait_threshold", cfg.ClaudeWaitThreshold},
+		{"auto_quit_after", cfg.AutoQuitAfter},
+		{"active_within", cfg.ActiveWithin},
+		{"layout_timeout", cfg.LayoutTimeout},
+	}
+	for _, d := range durations {
+		line, ok := lines[d.field]
+		if !ok {
+			continue
+		}
+		if parsed, err := time.ParseDuration(d.value); err != nil || parsed <= 0 {
+			problems = append(problems, Problem{
+				Line:    line,
+				Field:   d.field,
+				Message: fmt.Sprintf("%q is not a positive duration (e.g. 90s, 2m)", d.value),
+			})
+		}
+	}
+
+	if line, ok := lines["project_types"]; ok {
+		for i, marker := range cfg.ProjectTypes {
+			if marker.File == "" || marker.Icon == "" {
+				problems = append(problems, Problem{
+					Line:    line,
+					Field:   fmt.Sprintf("project_types[%d]", i),
+					Message: "file and icon are required",
+				})
+			}
+		}
+	}
+
+	if line, ok := lines["templates"]; ok {
+		problems = append(problems, validateTemplates(cfg.Templates, line)...)
+	}
+	return problems
+}
+
+// validateTemplates checks template names are set and unique and every window is named
+func validateTemplates(templates []Template, line int) []Problem {
+	var problems []Problem
+	seen := make(map[string]bool)
+	for i, t := range templates {
+		field := fmt.Sprintf("templates[%d]", i)
+		switch {
+		case t.Name == "":
+			problems = append(problems, Problem{Line: line, Field: field, Message: "name is required"})
+		case seen[t.Name]:
+			problems = append(problems, Problem{Line: line, Field: field, Message: fmt.Sprintf("duplicate template name %q", t.Name)})
+		}
+		seen[t.Name] = true
+
+		if len(t.Windows) == 0 {
+			problems = append(problems, Problem{Line: line, Field: field, Message: "needs at least one window"})
+		}
+		for j, w := range t.Windows {
+			if w.Name == "" {
+				problems = append(problems, Problem{Line: line, Field: fmt.Sprintf("%s.windows[%d]", field, j), Message: "name is required"})
+			}
+		}
+	}
+	return problems
+}
+
+// validPopupSize reports whether s is a tmux popup size: cells ("80") or a percentage ("90%")
+func validPopupSize(s string) bool {
+	if pct, ok := strings.CutSuffix(s, "%"); ok {
+		n, err := strconv.Atoi(pct)
+		return err == nil && n > 0 && n <= 100
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n > 0
+}
+
+// keyLines maps each top-level YAML key to the line it is defined on
+func keyLines(data []byte) map[string]int {
+	lines := make(map[string]int)
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return lines
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return lines
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		lines[root.Content[i].Value] = root.Content[i].Line
+	}
+	return lines
+}
+
+// unknownFieldRe matches the error yaml.v3 reports for unknown keys in strict mode
+var unknownFieldRe = regexp.MustCompile(`^field (\S+) not found in type`)
+
+// yamlLineRe matches the "line N: " prefix yaml.v3 puts on error messages
+var yamlLineRe = regexp.MustCompile(`^line (\d+): `)
+
+// parseProblems converts a yaml.v3 error into problems with line numbers
+func parseProblems(err error, warning bool) []Problem {
+	if err == nil {
+		return nil
+	}
+
+	var messages []string
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	} else {
+		messages = []string{strings.TrimPrefix(err.Error(), "yaml: ")}
+	}
+
+	problems := make([]Problem, 0, len(messages))
+	for _, msg := range messages {
+		p := Problem{Message: msg, Warning: warning}
+		if m := yamlLineRe.FindStringSubmatch(msg); m != nil {
+			p.Line, _ = strconv.Atoi(m[1])
+			p.Message = strings.TrimPrefix(msg, m[0])
+		}
+		problems = append(problems, p)
+	}
+	return problems
+}
@@ -26,15 +26,24 @@ type SyncStatus struct {
 	Ahead  int
 	Behind int
 	Dirty  int
+	Base   string // Default branch Ahead/Behind count against when there is no upstream ("" = upstream)
 }
 
 // GetSyncStatus returns the sync state of a git repository at dir.
+// Without an upstream the state stays no-upstream, but Ahead/Behind are counted
+// against origin's default branch (named in Base) when it is known.
 func GetSyncStatus(dir string) SyncStatus {
 	dirty := getDirtyCount(dir)
 
 	// Check if there's an upstream tracking branch
 	if _, err := exec.Command("git", "-C", dir, "rev-parse", "--abbrev-ref", "@{u}").Output(); err != nil {
-		return SyncStatus{State: StateNoUpstream, Dirty: dirty}
+		status := SyncStatus{State: StateNoUpstream, Dirty: dirty}
+		if base, err := DefaultBranch(dir); err == nil {
+			status.Base = base
+			status.Ahead = revListCount(dir, "origin/"+base+"..")
+			status.Behind = revListCount(dir, "..origin/"+base)
+		}
+		return status
 	}
 
 	ahead := revListCount(dir, "@{u}..")
@@ -49,6 +58,15 @@ func GetSyncStatus(dir string) SyncStatus {
 	}
 }
 
+// RemoteURL returns the URL of the origin remote of the repository at dir.
+func RemoteURL(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "remote", "get-url", "origin").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 // Fetch runs git fetch --all --quiet in the given directory.
 func Fetch(dir string) error {
 	cmd := exec.Command("git", "-C", dir, "fetch", "--all", "--quiet")
@@ -68,6 +86,15 @@ func Push(dir string) error {
 	return cmd.Run()
 }
 
+// TopLevel returns the root of the work tree containing dir.
+func TopLevel(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 // GetBranch returns the current branch name for the repo at dir.
 func GetBranch(dir string) (string, error) {
 	out, err := exec.Command("git", "-C", dir, "rev-parse", "--abbrev-ref", "HEAD").Output()
@@ -77,6 +104,17 @@ func GetBranch(dir string) (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
+// DefaultBranch returns the name of origin's default branch (e.g. "main") for the
+// repo at dir, from origin/HEAD. It is unset in repos that weren't cloned until
+// `git remote set-head origin --auto` is run.
+func DefaultBranch(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "symbolic-ref", "--short", "refs/remotes/origin/HEAD").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(out)), "origin/"), nil
+}
+
 // revListCount runs git rev-list --count with the given revspec and returns the count.
 func revListCount(dir, revspec string) int {
 	out, err := exec.Command("git", "-C", dir, "rev-list", "--count", revspec).Output()
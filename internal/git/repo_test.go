@@ -0,0 +1,36 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// gitCmd runs git with args, failing the test on error
+func gitCmd(t *testing.T, args ...string) {
+	t.Helper()
+	args = append([]string{"-c", "user.name=helm", "-c", "user.email=helm@example.com"}, args...)
+	if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+}
+
+func TestDefaultBranch(t *testing.T) {
+	if !Available() {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	origin, clone := filepath.Join(root, "origin"), filepath.Join(root, "clone")
+	gitCmd(t, "init", "-q", "-b", "trunk", origin)
+	gitCmd(t, "-C", origin, "commit", "-q", "--allow-empty", "-m", "init")
+	gitCmd(t, "clone", "-q", origin, clone)
+
+	if got, err := DefaultBranch(clone); err != nil || got != "trunk" {
+		t.Errorf("DefaultBranch() with origin/HEAD = %q, %v, want trunk", got, err)
+	}
+
+	gitCmd(t, "-C", clone, "remote", "set-head", "origin", "--delete")
+	if got, err := DefaultBranch(clone); err == nil {
+		t.Errorf("DefaultBranch() without origin/HEAD = %q, want an error", got)
+	}
+}
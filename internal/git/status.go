@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // Status represents git repository status for a session
@@ -20,18 +21,19 @@ func (s Status) IsClean() bool {
 	return !s.IsRepo || (s.Dirty == 0 && s.Additions == 0 && s.Deletions == 0)
 }
 
-// GetSessionPath returns the current working directory of a tmux session's active pane
-func GetSessionPath(sessionName string) (string, error) {
-	out, err := exec.Command("tmux", "display-message", "-t", sessionName, "-p", "#{pane_current_path}").Output()
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSpace(string(out)), nil
-}
+// Available reports whether git is installed (looked up in PATH once, then cached)
+var Available = sync.OnceValue(func() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+})
 
 // GetStatus returns the git status for a directory
-// Returns Status{IsRepo: false} if the directory is not a git repository
+// Returns Status{IsRepo: false} if the directory is not a git repository or git is missing
 func GetStatus(dir string) Status {
+	if !Available() {
+		return Status{IsRepo: false}
+	}
+
 	// Check if this is a git repo by looking for .git
 	gitDir := filepath.Join(dir, ".git")
 	if !isDir(gitDir) && !isFile(gitDir) {
@@ -9,6 +9,12 @@ import (
 	"strings"
 )
 
+// GhInstalled reports whether the gh CLI is on PATH
+func GhInstalled() bool {
+	_, err := exec.LookPath("gh")
+	return err == nil
+}
+
 // CheckGhCli verifies that gh CLI is installed and authenticated
 func CheckGhCli() error {
 	// Check if gh is installed
@@ -24,41 +30,80 @@ func CheckGhCli() error {
 	return nil
 }
 
-// FetchAvailableRepos returns repos the user has access to (owner/repo format)
-func FetchAvailableRepos() ([]string, error) {
-	out, err := exec.Command("gh", "api", "/user/repos", "--paginate", "--jq", ".[].full_name").Output()
+// Repo is a repository available to clone
+type Repo struct {
+	FullName    string // owner/repo
+	Description string // Only set when fetched with details
+	Language    string // Primary language, only set when fetched with details
+}
+
+// FetchAvailableRepos returns repos the user has access to
+// With details, description and primary language are fetched as well (slower for large listings)
+func FetchAvailableRepos(details bool) ([]Repo, error) {
+	jq := ".[].full_name"
+	if details {
+		jq = `.[] | [.full_name, (.description // ""), (.language // "")] | @tsv`
+	}
+
+	out, err := exec.Command("gh", "api", "/user/repos", "--paginate", "--jq", jq).Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch repositories: %w", err)
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
-	if len(lines) == 0 || (len(lines) == 1 && lines[0] == "") {
-		return []string{}, nil
-	}
+	return parseRepoLines(string(out)), nil
+}
 
-	return lines, nil
+// parseRepoLines parses gh output: one repo per line, optionally with
+// tab-separated description and language columns
+func parseRepoLines(out string) []Repo {
+	repos := []Repo{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		repo := Repo{FullName: fields[0]}
+		if len(fields) > 1 {
+			repo.Description = fields[1]
+		}
+		if len(fields) > 2 {
+			repo.Language = fields[2]
+		}
+		repos = append(repos, repo)
+	}
+	return repos
 }
 
-// CloneRepo clones a repository to the specified destination path
+// CloneRepo clones a GitHub repository (owner/repo) over SSH to the specified destination path
 func CloneRepo(ownerRepo, destPath string) error {
+	return CloneURL(fmt.Sprintf("git@github.com:%s.git", ownerRepo), destPath)
+}
+
+// CloneURL clones any git URL to the specified destination path with plain git
+func CloneURL(url, destPath string) error {
 	// Ensure parent directory exists
 	parentDir := filepath.Dir(destPath)
 	if err := os.MkdirAll(parentDir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory %s: %w", parentDir, err)
 	}
 
-	// Construct SSH URL
-	gitURL := fmt.Sprintf("git@github.com:%s.git", ownerRepo)
-
 	// Clone the repository
-	cmd := exec.Command("git", "clone", gitURL, destPath)
+	cmd := exec.Command("git", "clone", url, destPath)
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to clone %s: %w", ownerRepo, err)
+		return fmt.Errorf("failed to clone %s: %w", url, err)
 	}
 
 	return nil
 }
 
+// RepoFromURL returns owner/repo if url points at github.com, "" otherwise
+func RepoFromURL(url string) string {
+	if !strings.Contains(url, "github.com") {
+		return ""
+	}
+	return ParseGitURL(url)
+}
+
 // ParseGitURL extracts owner/repo from a git URL (SSH or HTTPS).
 // Returns empty string if the URL cannot be parsed.
 func ParseGitURL(url string) string {
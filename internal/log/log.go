@@ -0,0 +1,72 @@
+// Package log writes an optional debug log, since bubbletea owns stdout while the TUI runs
+// Every function is a no-op until Open is called (debug: true or HELM_DEBUG=1)
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileName is the debug log's name inside cache_dir
+const FileName = "debug.log"
+
+var (
+	mu   sync.Mutex
+	file *os.File
+)
+
+// Open starts appending to dir/debug.log, creating dir if needed
+func Open(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, FileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open debug log: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if file != nil {
+		_ = file.Close()
+	}
+	file = f
+	return nil
+}
+
+// Close stops logging
+func Close() {
+	mu.Lock()
+	defer mu.Unlock()
+	if file != nil {
+		_ = file.Close()
+		file = nil
+	}
+}
+
+// Enabled reports whether the debug log is open, to skip building expensive messages
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return file != nil
+}
+
+// Printf writes a timestamped line, e.g. "2026-01-02 15:04:05.000 tmux list-sessions (2ms)"
+func Printf(format string, args ...any) {
+	mu.Lock()
+	defer mu.Unlock()
+	if file == nil {
+		return
+	}
+	line := strings.TrimRight(fmt.Sprintf(format, args...), "\n")
+	fmt.Fprintf(file, "%s %s\n", time.Now().Format("2006-01-02 15:04:05.000"), line)
+}
+
+// Since logs how long an operation took; use as defer log.Since("load", time.Now())
+func Since(what string, start time.Time) {
+	Printf("%s (%s)", what, time.Since(start).Round(time.Microsecond))
+}
@@ -0,0 +1,42 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrintfOnlyWhenOpen(t *testing.T) {
+	dir := t.TempDir()
+
+	// Not open yet: nothing is written
+	Printf("dropped")
+	if Enabled() {
+		t.Fatal("Enabled() = true before Open")
+	}
+
+	if err := Open(dir); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	Printf("tmux %s", "list-sessions")
+	Since("load sessions", time.Now())
+	Close()
+	Printf("after close")
+
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), data)
+	}
+	if !strings.HasSuffix(lines[0], " tmux list-sessions") {
+		t.Errorf("line 0 = %q", lines[0])
+	}
+	if !strings.Contains(lines[1], " load sessions (") {
+		t.Errorf("line 1 = %q", lines[1])
+	}
+}
@@ -1,22 +1,34 @@
 package model
 
 import (
+	"cmp"
+	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"github.com/black-atom-industries/helm/internal/claude"
+	"github.com/black-atom-industries/helm/internal/clipboard"
 	"github.com/black-atom-industries/helm/internal/config"
 	"github.com/black-atom-industries/helm/internal/git"
 	"github.com/black-atom-industries/helm/internal/github"
+	"github.com/black-atom-industries/helm/internal/log"
+	"github.com/black-atom-industries/helm/internal/project"
+	"github.com/black-atom-industries/helm/internal/shell"
 	"github.com/black-atom-industries/helm/internal/tmux"
 	"github.com/black-atom-industries/helm/internal/ui"
 )
@@ -32,7 +44,14 @@ const (
 	ModeConfirmRemoveFolder
 	ModeCloneRepo
 	ModeBookmarks
-	ModeCreatePath // Path input for creating session at arbitrary path
+	ModeCreatePath         // Path input for creating session at arbitrary path
+	ModeMoveWindow         // Target session picker for moving/linking a window
+	ModeLog                // Recent status/error messages, newest first
+	ModeSettings           // Toggle common config options, saved to settings.yml
+	ModeConfirmQuit        // Quit anyway with dirty repos or watched commands running (quitWarning)
+	ModeGoTo               // Multi-digit session number prompt
+	ModeConfirmClearClaude // Delete every Claude status file, even of running sessions (F8)
+	ModeBroadcast          // Command prompt, then confirmation, for typing into every pane of a session
 )
 
 // String returns the display name for the mode (used in title bar)
@@ -54,11 +73,41 @@ func (m Mode) String() string {
 		return "KILL"
 	case ModeConfirmRemoveFolder:
 		return "DEL"
+	case ModeMoveWindow:
+		return "MOVE"
+	case ModeLog:
+		return "LOG"
+	case ModeSettings:
+		return "SET"
+	case ModeConfirmQuit:
+		return "QUIT"
+	case ModeGoTo:
+		return "GOTO"
+	case ModeConfirmClearClaude:
+		return "CLEAR"
+	case ModeBroadcast:
+		return "SEND"
 	default:
 		return "SESS"
 	}
 }
 
+// Accent returns the title bar badge color for the mode, grouping related modes
+func (m Mode) Accent() lipgloss.TerminalColor {
+	switch m {
+	case ModeBookmarks:
+		return ui.Colors.Bg.ViewBookmarks
+	case ModePickDirectory, ModeCreate, ModeCreatePath, ModeMoveWindow:
+		return ui.Colors.Bg.ViewPick
+	case ModeCloneRepo:
+		return ui.Colors.Bg.ViewClone
+	case ModeConfirmKill, ModeConfirmRemoveFolder, ModeConfirmQuit, ModeConfirmClearClaude, ModeBroadcast:
+		return ui.Colors.Bg.ViewDanger
+	default:
+		return ui.Colors.Bg.ViewSessions
+	}
+}
+
 // ItemType represents the type of item in the flattened list
 type ItemType int
 
@@ -66,6 +115,7 @@ const (
 	ItemTypeSession ItemType = iota
 	ItemTypeWindow
 	ItemTypePane
+	ItemTypeRecent // Recent project without a session (recent_projects)
 )
 
 // Item represents a session, window, or pane in the flattened list
@@ -74,26 +124,47 @@ type Item struct {
 	SessionIndex int // Index in the sessions slice
 	WindowIndex  int // Index in the session's windows slice (for windows and panes)
 	PaneIndex    int // Index in the window's panes slice (for panes only)
+	RecentIndex  int // Index in recentRows (for recent projects only)
 }
 
 // Model is the main application state
 type Model struct {
+	client            tmux.Client // nil = tmux.ExecClient (see tmuxClient)
 	sessions          []tmux.Session
 	claudeStatuses    map[string]claude.Status
 	gitStatuses       map[string]git.Status
 	currentSession    string
+	currentPath       string // Working directory of the current session (resolved async)
 	cursor            int
 	items             []Item // Flattened list of visible items
 	mode              Mode
 	message           string
 	messageIsError    bool
+	welcome           bool          // First-run banner is showing; dismissed by the next key
+	lastKeyAt         time.Time     // Time of the last key press (auto_quit_after)
+	currentInfo       *tmux.Session // Current session shown above the list (show_current)
+	messages          []logEntry    // Recent messages, newest first (capped at maxLogEntries)
+	logOffset         int           // Scroll offset in the message log view
+	settingsCursor    int           // Selected row in the settings view
 	input             textinput.Model
-	killTarget        string // Name of session/window being killed
-	removeTarget      string // Full path of folder being removed
+	killTarget        string          // Name of session/window being killed
+	selected          map[string]bool // Items marked for a batch kill (Space), by target name
+	killRemovePath    string          // Directory deleted along with killTarget (C-d in the kill prompt)
+	killRemoveDirty   int             // Uncommitted changes in killRemovePath (> 0 needs a second C-x)
+	killRemoveArmed   bool            // First C-x pressed for a dirty killRemovePath
+	removeTarget      string          // Full path of folder being removed
+	removeDirty       int             // Uncommitted changes in removeTarget (> 0 needs a second C-x)
+	removeArmed       bool            // First C-x pressed for a dirty removeTarget
+	broadcastTarget   string          // Session whose panes get the typed command (ModeBroadcast)
+	broadcastCommand  string          // Command awaiting confirmation ("" while typing it)
 	config            config.Config
 	maxNameWidth      int    // For column alignment
 	maxGitStatusWidth int    // For git status column alignment
 	filter            string // Current filter text for fuzzy matching
+	compact           bool   // Compact rows: "N name" only, no columns or table header
+	gotoInput         string // Digits typed into the go-to prompt (ModeGoTo)
+	createExists      bool   // The create input (sanitized) names an existing session
+	showAllSessions   bool   // F3: ignore max_sessions and active_within
 
 	// Directory picker state (uses ScrollList for cursor/scroll/filter)
 	projectList        *ui.ScrollList[string]
@@ -124,41 +195,105 @@ type Model struct {
 	cloneSuccess        bool   // True when clone completed, awaiting confirmation
 	cloneSuccessPath    string // Path of cloned repo (for layout)
 	cloneSuccessSession string // Session name to switch to
+	cloneURLMode        bool   // The prompt takes a git URL instead of filtering the list
+	cloneNoGh           bool   // gh CLI missing: URL input is the only way to clone
+
+	// Optional description/language per repo (clone_details_enabled)
+	cloneDetails map[string]github.Repo
+
+	// Recent projects shown below the sessions (recent_projects)
+	recentHistory  []string // Recently opened project paths, newest first
+	recentProjects []string // History paths without a session, checked on disk once per load
+	recentRows     []string // Paths currently shown: recentProjects unless filtering
+
+	// Favorite session names (C-z), listed first; persisted in cache_dir
+	starred map[string]bool
+
+	// Up/down history of the create prompt (C-n), persisted in cache_dir
+	createHistory    []string // Submitted inputs ("name" or "name -- command"), newest first
+	createHistoryPos int      // Shown entry while browsing, -1 = the typed input
+	createDraft      string   // Typed input, restored when browsing back down
+
+	// Project type icons (project_icons_enabled); detection is cached per path
+	projectDetector *project.Detector
+	projectIcons    map[string]string // Session name -> icon
+
+	// Working directory per session name, resolved once per load (see sessionPath)
+	// Matched by the filter and used by git statuses and path-dependent actions
+	sessionPaths map[string]string
+
+	// Working directory per window/pane target, resolved when the cursor lands on it
+	itemPaths map[string]string
+
+	// Pinned runtime versions (env_versions_enabled), read only for visible rows
+	envReader   *project.EnvReader
+	envVersions map[string]string // Session name -> badge ("" while loading or none)
+
+	// Move/link window state (uses ScrollList for target session picker)
+	moveList         *ui.ScrollList[string]
+	moveSourceName   string // Session owning the window being moved
+	moveSourceWindow int    // tmux index of the window being moved
 
 	// Bookmarks mode state (uses ScrollList for cursor/scroll/filter)
 	bookmarkList     *ui.ScrollList[config.Bookmark]
 	bookmarkExpanded map[string]bool // Tracks which bookmarks are expanded (by path)
 
 	// Loading state
-	sessionsLoaded bool // True after sessions have been loaded at least once
+	sessionsLoaded       bool // True after sessions have been loaded at least once
+	expandedOnStart      bool // True once expand_on_start has been applied
+	filterWindowsLoading bool // True while windows for the filter are loaded in the background
+
+	// Print mode: record the chosen target instead of switching (for shell integration)
+	printSelection bool
+	selection      string
+
+	// Read-only (helm monitor): kill, remove, move and bookmark removal are disabled
+	readOnly bool
+
+	// List _popup_ sessions too (helm --all or C-v), e.g. to kill orphaned ones
+	showPopups bool
+
+	// The jump_prefix key was pressed; a digit next jumps, anything else filters
+	jumpPending bool
+
+	// gh CLI is installed (looked up once in New); the help leaves out PRs/issues without it
+	ghInstalled bool
 
 	// Git status loading state
 	gitStatusPending     map[string]bool // Sessions still being fetched (by name)
 	gitStatusShowLoading bool            // True after 500ms delay if still loading
 }
 
+// Input limits: the create input holds "name -- command", but the name part
+// is cut to sessionNameLimit after splitting (see splitCreateInput)
+// The broadcast prompt shares the input without a limit (0), since a cut
+// command would be sent to every pane
+const (
+	sessionNameLimit = 50
+	createInputLimit = 256
+)
+
 // New creates a new Model
 func New(currentSession string, cfg config.Config) Model {
 	ti := textinput.New()
 	ti.Prompt = "" // We handle the prompt in RenderPrompt
-	ti.CharLimit = 50
+	ti.CharLimit = createInputLimit
 
 	// Path input for ModeCreatePath
 	pathInput := textinput.New()
 	pathInput.Prompt = ""
 	pathInput.CharLimit = 256
 
-	// Create project list with filter function that matches on directory basename
-	projectList := ui.NewScrollList(func(fullPath string, filter string) bool {
-		name := filepath.Base(fullPath)
-		return strings.Contains(strings.ToLower(name), filter)
-	})
-
 	// Create clone list with filter function that matches on repo name
 	cloneList := ui.NewScrollList(func(repo string, filter string) bool {
 		return strings.Contains(strings.ToLower(repo), filter)
 	})
 
+	// Create move target list with filter function that matches on session name
+	moveList := ui.NewScrollList(func(name string, filter string) bool {
+		return strings.Contains(strings.ToLower(name), filter)
+	})
+
 	// Create bookmark list with filter function that matches on path basename
 	bookmarkList := ui.NewScrollList(func(b config.Bookmark, filter string) bool {
 		name := filepath.Base(b.Path)
@@ -171,58 +306,362 @@ func New(currentSession string, cfg config.Config) Model {
 		input:            ti,
 		pathInput:        pathInput,
 		config:           cfg,
-		projectList:      projectList,
+		compact:          cfg.Compact,
+		projectList:      newProjectList(cfg),
 		cloneList:        cloneList,
+		moveList:         moveList,
 		bookmarkList:     bookmarkList,
 		bookmarkExpanded: make(map[string]bool),
+		lastKeyAt:        time.Now(),
+		ghInstalled:      github.GhInstalled(),
+	}
+
+	if cfg.ProjectIconsEnabled {
+		m.projectDetector = project.NewDetector(cfg.ProjectTypes)
 	}
+	if cfg.EnvVersionsEnabled {
+		m.envReader = project.NewEnvReader(cfg.EnvFiles)
+	}
+	if cfg.RecentProjects > 0 {
+		m.recentHistory = m.loadRecentHistory()
+	}
+	m.starred = m.loadStarred()
 
 	// Load cached sessions for instant startup
 	if cached := m.loadSessionCache(); cached != nil {
 		m.sessions = cached
+		m.sortStarred()
 		m.sessionsLoaded = true
 		m.calculateColumnWidths()
 		// Reserve git status column to prevent layout shift when statuses load
-		if cfg.GitStatusEnabled {
+		if m.gitStatusEnabled() {
 			m.maxGitStatusWidth = ui.GitStatusColumnWidth
 		}
+		m.recentProjects = m.recentProjectsWithoutSession()
 		m.rebuildItems()
 	}
 
+	// First run without a config file: point at `helm init` once
+	if !config.Exists() && !m.welcomeSeen() {
+		m.setMessage(welcomeMessage)
+		m.welcome = true
+	}
+
 	return m
 }
 
+// welcomeMessage is the one-time banner shown when no config file exists
+const welcomeMessage = "Welcome! Run `helm init` for a config · C-p projects (~/repos) · C-n new · C-b bookmarks"
+
+// welcomeMarkerPath returns the path of the file recording that the banner was shown
+func (m *Model) welcomeMarkerPath() string {
+	return filepath.Join(m.config.CacheDir, "welcome_seen")
+}
+
+// welcomeSeen reports whether the first-run banner was already shown
+func (m *Model) welcomeSeen() bool {
+	_, err := os.Stat(m.welcomeMarkerPath())
+	return err == nil
+}
+
+// markWelcomeSeenCmd persists that the first-run banner was shown, once the
+// program runs (so constructing a Model never writes to disk)
+// Returns nil when the banner isn't showing
+func (m Model) markWelcomeSeenCmd() tea.Cmd {
+	if !m.welcome {
+		return nil
+	}
+	cacheDir, marker := m.config.CacheDir, m.welcomeMarkerPath()
+	return func() tea.Msg {
+		if err := os.MkdirAll(cacheDir, 0755); err != nil {
+			return nil
+		}
+		_ = os.WriteFile(marker, nil, 0644)
+		return nil
+	}
+}
+
+// SetPrintSelection makes the model record the chosen target instead of
+// switching the tmux client; read it with Selection after the program exits
+func (m *Model) SetPrintSelection(enabled bool) {
+	m.printSelection = enabled
+}
+
+// SetReadOnly disables destructive actions, for use as a status dashboard
+func (m *Model) SetReadOnly(enabled bool) {
+	m.readOnly = enabled
+}
+
+// SetShowPopups lists the _popup_ sessions that are normally hidden
+func (m *Model) SetShowPopups(enabled bool) {
+	m.showPopups = enabled
+}
+
+// denyReadOnly reports (and flashes a message) when a destructive action is blocked
+// Callers return clearMessageAfter(readOnlyMessageDuration) so the message clears
+func (m *Model) denyReadOnly() bool {
+	if !m.readOnly {
+		return false
+	}
+	m.setMessage("Read-only: not available in monitor mode")
+	return true
+}
+
+// readOnlyMessageDuration is how long denyReadOnly's message stays up
+const readOnlyMessageDuration = 2 * time.Second
+
+// denyPopups reports whether popups are turned off (disable_popups), and says so
+func (m *Model) denyPopups() bool {
+	if !m.config.DisablePopups {
+		return false
+	}
+	m.setMessage("Popups are disabled (disable_popups)")
+	return true
+}
+
+// tmuxClient returns the client tmux commands go through: the real tmux binary
+// unless a test set a fake
+func (m Model) tmuxClient() tmux.Client {
+	if m.client == nil {
+		return tmux.ExecClient{}
+	}
+	return m.client
+}
+
+// Selection returns the target chosen in print mode ("" if nothing was chosen)
+func (m Model) Selection() string {
+	return m.selection
+}
+
+// switchClient switches the tmux client to target, or records it in print mode
+func (m *Model) switchClient(target string) error {
+	if m.printSelection {
+		m.selection = target
+		return nil
+	}
+	return m.tmuxClient().SwitchClient(target)
+}
+
 // Init implements tea.Model
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(m.loadSessions, animationTick())
+	// Request the size right away so popups don't render with fallback dimensions
+	return tea.Batch(tea.WindowSize(), m.loadSessions, m.loadCurrentPath, animationTick(), m.reloadTick(),
+		m.idleTick(m.config.AutoQuitDuration()), m.markWelcomeSeenCmd())
+}
+
+// idleTick schedules the next auto-quit check after d
+// Returns nil when auto_quit_after is unset (disabled)
+func (m Model) idleTick(d time.Duration) tea.Cmd {
+	if d <= 0 {
+		return nil
+	}
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return idleTickMsg{}
+	})
+}
+
+// autoSwitchDelay is how long typing must pause before auto_switch_on_unique fires
+const autoSwitchDelay = 400 * time.Millisecond
+
+// autoSwitchTick schedules an auto-switch check for the current filter
+// Returns nil when auto_switch_on_unique is off
+func (m Model) autoSwitchTick() tea.Cmd {
+	if !m.config.AutoSwitchOnUnique {
+		return nil
+	}
+	filter := m.filter
+	return tea.Tick(autoSwitchDelay, func(time.Time) tea.Msg {
+		return autoSwitchMsg{filter: filter}
+	})
+}
+
+// uniqueSessionItem returns the index of the only session item, if exactly one matches
+func (m *Model) uniqueSessionItem() (int, bool) {
+	found := -1
+	for i, item := range m.items {
+		if item.Type == ItemTypeWindow || item.Type == ItemTypePane {
+			continue
+		}
+		if found >= 0 || item.Type != ItemTypeSession {
+			return 0, false
+		}
+		found = i
+	}
+	return found, found >= 0
+}
+
+// busy reports whether helm is waiting on work the user started (fetching or cloning repos)
+func (m Model) busy() bool {
+	return m.cloneLoading || m.cloneCloning
+}
+
+// reloadTick schedules the next automatic session reload
+// Returns nil when refresh_interval is 0 (disabled)
+func (m Model) reloadTick() tea.Cmd {
+	if m.config.RefreshInterval <= 0 {
+		return nil
+	}
+	interval := time.Duration(m.config.RefreshInterval) * time.Second
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return reloadMsg{}
+	})
+}
+
+// loadCurrentPath resolves the working directory of the current session
+func (m Model) loadCurrentPath() tea.Msg {
+	if m.currentSession == "" {
+		return nil
+	}
+	path, err := m.tmuxClient().DisplayPath(m.currentSession)
+	if err != nil {
+		return nil
+	}
+	return currentPathMsg{path}
 }
 
 // loadSessions fetches sessions from tmux
+// With include_current, the current session is listed like any other; otherwise
+// with show_current it is split out for its own row
 func (m Model) loadSessions() tea.Msg {
-	sessions, err := tmux.ListSessions(m.currentSession)
+	defer log.Since("load sessions", time.Now())
+	if m.config.IncludeCurrent {
+		sessions, err := m.tmuxClient().ListSessions("", m.showPopups)
+		if err != nil {
+			return errMsg{err}
+		}
+		setWindowCounts(m.tmuxClient(), sessions)
+		setPaneStates(m.tmuxClient(), sessions, m.config.WatchCommands)
+		return sessionsMsg{sessions: sessions}
+	}
+
+	if !m.config.ShowCurrent {
+		sessions, err := m.tmuxClient().ListSessions(m.currentSession, m.showPopups)
+		if err != nil {
+			return errMsg{err}
+		}
+		setWindowCounts(m.tmuxClient(), sessions)
+		setPaneStates(m.tmuxClient(), sessions, m.config.WatchCommands)
+		return sessionsMsg{sessions: sessions}
+	}
+
+	all, err := m.tmuxClient().ListSessions("", m.showPopups)
 	if err != nil {
 		return errMsg{err}
 	}
-	return sessionsMsg{sessions}
+	setWindowCounts(m.tmuxClient(), all)
+	setPaneStates(m.tmuxClient(), all, m.config.WatchCommands)
+	msg := sessionsMsg{}
+	for i := range all {
+		if all[i].Name == m.currentSession {
+			msg.current = &all[i]
+			continue
+		}
+		msg.sessions = append(msg.sessions, all[i])
+	}
+	return msg
+}
+
+// setWindowCounts fills in WindowCount for each session (left at 0 on error)
+func setWindowCounts(client tmux.Client, sessions []tmux.Session) {
+	counts, err := client.WindowCounts()
+	if err != nil {
+		return
+	}
+	for i := range sessions {
+		sessions[i].WindowCount = counts[sessions[i].Name]
+	}
+}
+
+// paneInfo formats an active pane as "command · title", leaving out a title
+// that only repeats the command
+func paneInfo(command, title string) string {
+	if title == "" || title == command {
+		return command
+	}
+	if command == "" {
+		return title
+	}
+	return command + " · " + title
+}
+
+// setPaneStates flags sessions with a pane running one of commands and the
+// session holding tmux's marked pane, and records each active pane's command
+// and title and the number of dead panes (left unset on error)
+func setPaneStates(client tmux.Client, sessions []tmux.Session, commands []string) {
+	scan, err := client.ScanPanes(commands)
+	if err != nil {
+		return
+	}
+	for i := range sessions {
+		sessions[i].Watched = scan.Running[sessions[i].Name]
+		sessions[i].PaneMarked = sessions[i].Name == scan.MarkedSession
+		active := scan.Active[sessions[i].Name]
+		sessions[i].PaneCommand, sessions[i].PaneTitle = active.Command, active.Title
+		sessions[i].DeadPanes = scan.Dead[sessions[i].Name]
+	}
 }
 
 type sessionsMsg struct {
 	sessions []tmux.Session
+	current  *tmux.Session // Current session (only loaded with show_current)
 }
 
 type errMsg struct {
 	err error
 }
 
+type currentPathMsg struct {
+	path string
+}
+
 type clearMessageMsg struct{}
 
 type animationTickMsg struct{}
 
+type reloadMsg struct{}
+
+// sessionPathMsg carries one session's resolved working directory (and project icon)
+type sessionPathMsg struct {
+	name string
+	path string // "" if it couldn't be resolved
+	icon string // "" unless project_icons_enabled and the type was detected
+}
+
+// respawnedMsg reports the dead panes respawned in a session (F9)
+type respawnedMsg struct {
+	session   string
+	respawned int
+	err       error
+}
+
+// itemPathMsg carries the resolved working directory of a window or pane target
+type itemPathMsg struct {
+	target string
+	path   string
+}
+
+// envVersionsMsg carries env version badges by session name
+type envVersionsMsg struct {
+	badges map[string]string
+}
+
+// autoSwitchMsg fires autoSwitchDelay after typing; filter is the filter at that time
+type autoSwitchMsg struct {
+	filter string
+}
+
+// idleTickMsg checks whether auto_quit_after has elapsed since the last key press
+type idleTickMsg struct{}
+
 // Clone repo mode messages
 type cloneReposLoadedMsg struct {
-	repos []string
+	repos   []string
+	details map[string]github.Repo // Description/language by owner/repo (clone_details_enabled)
 }
 
+// cloneNoGhMsg signals that gh is not installed and a URL must be typed instead
+type cloneNoGhMsg struct{}
+
 type cloneErrorMsg struct {
 	err error
 }
@@ -260,7 +699,21 @@ func animationTick() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case sessionsMsg:
-		m.sessions = msg.sessions
+		// Keep the cursor on the same target and carry over expansion state
+		var selectedTarget string
+		if m.isCursorValid() {
+			selectedTarget = m.getTargetName(m.items[m.cursor])
+		}
+		m.sessions = preserveSessionState(m.sessions, msg.sessions)
+		m.sortStarred()
+		m.currentInfo = msg.current
+		m.itemPaths = nil // Windows may have changed directory or index
+		// Window lists load in the background (windowsLoadedMsg)
+		windowCmds := []tea.Cmd{m.reloadExpandedWindowsCmd()}
+		if m.config.ExpandOnStart && !m.expandedOnStart {
+			m.expandedOnStart = true
+			windowCmds = append(windowCmds, m.expandOnStartCmd())
+		}
 		m.sessionsLoaded = true
 		m.saveSessionCache() // Cache for instant startup next time
 		m.loadClaudeStatuses()
@@ -269,21 +722,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.gitStatuses = make(map[string]git.Status)
 		}
 		// Reserve git status column width to prevent layout shift
-		if m.config.GitStatusEnabled {
+		if m.gitStatusEnabled() {
 			m.maxGitStatusWidth = ui.GitStatusColumnWidth
 		}
 		m.calculateColumnWidths()
+		m.recentProjects = m.recentProjectsWithoutSession()
 		m.rebuildItems()
+		m.restoreCursor(selectedTarget)
 		if len(m.items) == 0 {
 			m.message = "No other sessions. Press C-n to create one."
 		}
-		// Fetch git statuses asynchronously to avoid blocking UI
-		return m, m.fetchGitStatusesCmd()
+		// Each session's git status follows once its path is resolved (sessionPathMsg)
+		return m, tea.Batch(append(windowCmds, m.cleanupClaudeStatusCmd(), m.resolveSessionPathsCmd())...)
 
 	case errMsg:
 		m.setError("Error: %v", msg.err)
 		return m, nil
 
+	case currentPathMsg:
+		m.currentPath = msg.path
+		return m, nil
+
 	case clearMessageMsg:
 		m.message = ""
 		m.messageIsError = false
@@ -293,14 +752,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.animationFrame = (m.animationFrame + 1) % 3
 		return m, animationTick()
 
+	case reloadMsg:
+		// Only reload while browsing - other modes hold references into the list
+		if m.mode == ModeNormal {
+			return m, tea.Batch(m.loadSessions, m.reloadTick())
+		}
+		return m, m.reloadTick()
+
 	case cloneReposLoadedMsg:
 		m.cloneLoading = false
+		m.cloneDetails = msg.details
 		m.cloneList.SetItems(msg.repos)
-		if len(msg.repos) == 0 {
+		if len(msg.repos) == 0 && !m.cloneURLMode {
 			m.cloneError = "All repositories are already cloned!"
 		}
 		return m, nil
 
+	case cloneNoGhMsg:
+		m.cloneLoading = false
+		m.cloneURLMode = true
+		m.cloneNoGh = true
+		return m, nil
+
 	case cloneErrorMsg:
 		m.cloneLoading = false
 		m.cloneCloning = false
@@ -336,14 +809,100 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.fitToSize()
+		return m, m.envVersionsCmd()
+
+	case sessionPathMsg:
+		if m.sessionPaths == nil {
+			m.sessionPaths = make(map[string]string)
+		}
+		if msg.path != "" {
+			m.sessionPaths[msg.name] = msg.path
+		} else {
+			delete(m.sessionPaths, msg.name)
+		}
+		if msg.icon != "" {
+			if m.projectIcons == nil {
+				m.projectIcons = make(map[string]string)
+			}
+			m.projectIcons[msg.name] = msg.icon
+		} else {
+			delete(m.projectIcons, msg.name)
+		}
+		if m.config.NameDisplay == config.NameDisplayPath {
+			m.calculateColumnWidths() // Paths may be wider than the names
+		}
+		if strings.TrimPrefix(m.filter, pathFilterPrefix) != "" {
+			m.rebuildItems() // Path matches may have changed
+		}
+		return m, tea.Batch(m.gitStatusCmd(msg.name), m.envVersionsCmd())
+
+	case itemPathMsg:
+		if m.itemPaths == nil {
+			return m, nil // Sessions reloaded meanwhile
+		}
+		m.itemPaths[msg.target] = msg.path
+		return m, nil
+
+	case respawnedMsg:
+		if msg.err != nil {
+			m.setError("Failed to respawn dead panes in %s: %v", msg.session, msg.err)
+			return m, tea.Batch(m.loadSessions, clearMessageAfter(3*time.Second))
+		}
+		m.setMessage("Respawned %s in %s", pluralize(msg.respawned, "dead pane"), msg.session)
+		return m, tea.Batch(m.loadSessions, clearMessageAfter(2*time.Second))
+
+	case windowsLoadedMsg:
+		return m, m.applyLoadedWindows(msg)
+
+	case envVersionsMsg:
+		if m.envVersions == nil {
+			return m, nil // Turned off in the settings view meanwhile
+		}
+		for name, badge := range msg.badges {
+			m.envVersions[name] = badge
+		}
+		return m, nil
+
+	case autoSwitchMsg:
+		// Only act once typing has paused: a newer keystroke changed the filter
+		if m.mode != ModeNormal || msg.filter != m.filter {
+			return m, nil
+		}
+		if i, ok := m.uniqueSessionItem(); ok {
+			m.cursor = i
+			return m.selectCurrent()
+		}
 		return m, nil
 
+	case idleTickMsg:
+		// Key presses don't cancel the tick; re-arm it for the remaining time instead
+		timeout := m.config.AutoQuitDuration()
+		if m.busy() {
+			return m, m.idleTick(timeout)
+		}
+		if remaining := timeout - time.Since(m.lastKeyAt); remaining > 0 {
+			return m, m.idleTick(remaining)
+		}
+		return m, tea.Quit
+
 	case tea.KeyMsg:
-		return m.handleKey(msg)
+		m.lastKeyAt = time.Now()
+		before := m.mode
+		next, cmd := m.handleKey(msg)
+		if m.mode != before {
+			log.Printf("mode %s -> %s (%s)", before, m.mode, msg)
+		}
+		// Scrolling or filtering may have revealed rows without env versions,
+		// or moved the cursor to an item whose path isn't resolved yet
+		if follow := tea.Batch(m.envVersionsCmd(), m.selectedPathCmd()); follow != nil {
+			return next, tea.Batch(cmd, follow)
+		}
+		return next, cmd
 	}
 
-	// Handle text input updates in create mode
-	if m.mode == ModeCreate {
+	// Handle text input updates in create and broadcast mode
+	if m.mode == ModeCreate || m.mode == ModeBroadcast {
 		var cmd tea.Cmd
 		m.input, cmd = m.input.Update(msg)
 		return m, cmd
@@ -360,6 +919,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Any key dismisses the first-run banner
+	if m.welcome {
+		m.welcome = false
+		m.message = ""
+	}
+
 	switch m.mode {
 	case ModeNormal:
 		return m.handleNormalMode(msg)
@@ -377,6 +942,20 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleCloneRepoMode(msg)
 	case ModeBookmarks:
 		return m.handleBookmarksMode(msg)
+	case ModeMoveWindow:
+		return m.handleMoveWindowMode(msg)
+	case ModeLog:
+		return m.handleLogMode(msg)
+	case ModeSettings:
+		return m.handleSettingsMode(msg)
+	case ModeConfirmQuit:
+		return m.handleConfirmQuitMode(msg)
+	case ModeGoTo:
+		return m.handleGoToMode(msg)
+	case ModeConfirmClearClaude:
+		return m.handleConfirmClearClaudeMode(msg)
+	case ModeBroadcast:
+		return m.handleBroadcastMode(msg)
 	}
 	return m, nil
 }
@@ -384,9 +963,28 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	keys := ui.DefaultKeyMap
 
+	// A prefix not followed by a digit is typed into the filter, but only if this
+	// key is typed too (bound keys like Space drop it)
+	var pendingPrefix string
+	if m.jumpPending {
+		m.jumpPending = false
+		if num, ok := jumpNumber(msg); ok {
+			return m.handleJump(num)
+		}
+		if msg.Type == tea.KeyEsc {
+			return m, nil // Cancels just the prefix
+		}
+		pendingPrefix = m.config.JumpPrefix
+	}
+
+	// Number jumps (bare digits only when no filter active; see jumpDigit)
+	if num, ok := m.jumpDigit(msg); ok {
+		return m.handleJump(num)
+	}
+
 	switch {
 	case key.Matches(msg, keys.Quit):
-		return m, tea.Quit
+		return m.quit()
 
 	case key.Matches(msg, keys.Cancel):
 		// Escape: clear filter if active, otherwise quit
@@ -395,7 +993,7 @@ func (m *Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.rebuildItems()
 			return m, nil
 		}
-		return m, tea.Quit
+		return m.quit()
 
 	case key.Matches(msg, keys.Up):
 		if m.cursor > 0 {
@@ -415,6 +1013,12 @@ func (m *Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, keys.Collapse):
 		m.collapseCurrent()
 
+	case key.Matches(msg, keys.ExpandAll):
+		return m, m.expandAllCmd()
+
+	case key.Matches(msg, keys.CollapseAll):
+		return m, m.collapseAll()
+
 	case key.Matches(msg, keys.Select):
 		// If filter is active but no results, transition to path input mode
 		if m.filter != "" && len(m.items) == 0 {
@@ -449,16 +1053,23 @@ func (m *Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.filter = "" // Clear any active filter
 		// Reset input completely
 		m.input.Reset()
-		m.input.SetValue("")
+		m.input.CharLimit = createInputLimit
+		m.input.SetValue(m.newSessionName(time.Now()))
+		m.input.CursorEnd()
 		m.input.Focus()
+		m.checkCreateName()
+		m.createHistory = m.loadCreateHistory()
+		m.createHistoryPos = -1
 		return m, textinput.Blink
 
 	case key.Matches(msg, keys.PickDirectory):
+		carried := m.carriedFilter()
 		m.mode = ModePickDirectory
 		m.filter = ""               // Clear any active filter
 		m.returnToBookmarks = false // Coming from normal mode, not bookmarks
 		m.projectList.Reset()
 		m.projectList.SetItems(m.scanProjectDirectories())
+		m.projectList.SetFilter(carried)
 		// Request window size to get proper height for layout
 		return m, tea.WindowSize()
 
@@ -469,18 +1080,34 @@ func (m *Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.cloneBasePath = m.config.ProjectDirs[0]
+		carried := m.carriedFilter()
 		m.mode = ModeCloneRepo
 		m.filter = "" // Clear any active filter
 		m.cloneList.Reset()
 		m.cloneList.Clear()
+		m.cloneList.SetFilter(carried) // Kept when the repos arrive
 		m.cloneError = ""
 		m.cloneLoading = true
 		m.cloneCloning = false
+		m.cloneURLMode = false
+		m.cloneNoGh = false
 		return m, m.fetchAvailableReposCmd()
 
 	case key.Matches(msg, keys.Lazygit):
 		return m.openLazygit()
 
+	case key.Matches(msg, keys.FileManager):
+		return m.openFileManager()
+
+	case key.Matches(msg, keys.Duplicate):
+		return m.duplicateSession()
+
+	case key.Matches(msg, keys.PullRequests):
+		return m.openGitHubList("pr")
+
+	case key.Matches(msg, keys.Issues):
+		return m.openGitHubList("issue")
+
 	case key.Matches(msg, keys.Bookmarks):
 		m.mode = ModeBookmarks
 		m.filter = "" // Clear any active filter
@@ -491,27 +1118,98 @@ func (m *Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, keys.AddBookmark):
 		return m.addSelectedToBookmarks()
 
-	// Number jumps (only when no filter active)
-	case m.filter == "" && key.Matches(msg, keys.Jump0):
-		return m.handleJump(0)
-	case m.filter == "" && key.Matches(msg, keys.Jump1):
-		return m.handleJump(1)
-	case m.filter == "" && key.Matches(msg, keys.Jump2):
-		return m.handleJump(2)
-	case m.filter == "" && key.Matches(msg, keys.Jump3):
-		return m.handleJump(3)
-	case m.filter == "" && key.Matches(msg, keys.Jump4):
-		return m.handleJump(4)
-	case m.filter == "" && key.Matches(msg, keys.Jump5):
-		return m.handleJump(5)
-	case m.filter == "" && key.Matches(msg, keys.Jump6):
-		return m.handleJump(6)
-	case m.filter == "" && key.Matches(msg, keys.Jump7):
-		return m.handleJump(7)
-	case m.filter == "" && key.Matches(msg, keys.Jump8):
-		return m.handleJump(8)
-	case m.filter == "" && key.Matches(msg, keys.Jump9):
-		return m.handleJump(9)
+	case key.Matches(msg, keys.ToggleCompact):
+		m.compact = !m.compact
+		m.updateScrollOffset()
+		return m, nil
+
+	case key.Matches(msg, keys.TogglePopups):
+		// Orphaned popup sessions linger invisibly; listing them lets them be killed
+		m.showPopups = !m.showPopups
+		if m.showPopups {
+			m.setMessage("Showing %s sessions", tmux.PopupPrefix)
+		} else {
+			m.setMessage("Hiding %s sessions", tmux.PopupPrefix)
+		}
+		return m, tea.Batch(m.loadSessions, clearMessageAfter(2*time.Second))
+
+	case key.Matches(msg, keys.ToggleStar):
+		m.toggleStar()
+		return m, clearMessageAfter(2 * time.Second)
+
+	case key.Matches(msg, keys.ShowAll):
+		m.showAllSessions = !m.showAllSessions
+		m.rebuildItems()
+		m.updateScrollOffset()
+		return m, nil
+
+	case key.Matches(msg, keys.ToggleGit):
+		return m, m.toggleSetting("git_status_enabled", "Git status")
+
+	case key.Matches(msg, keys.ToggleClaude):
+		return m, m.toggleSetting("claude_status_enabled", "Claude status")
+
+	case key.Matches(msg, keys.Broadcast):
+		if m.denyReadOnly() {
+			return m, clearMessageAfter(readOnlyMessageDuration)
+		}
+		return m.startBroadcast()
+
+	case key.Matches(msg, keys.RespawnDead):
+		if m.denyReadOnly() {
+			return m, clearMessageAfter(readOnlyMessageDuration)
+		}
+		return m.respawnDeadPanes()
+
+	case key.Matches(msg, keys.ClearClaude):
+		if m.denyReadOnly() {
+			return m, clearMessageAfter(readOnlyMessageDuration)
+		}
+		m.message = "Delete all Claude status files, including those of running sessions?"
+		m.messageIsError = false
+		m.mode = ModeConfirmClearClaude
+		return m, nil
+
+	case key.Matches(msg, keys.Refresh):
+		m.setMessage("Refreshed")
+		return m, tea.Batch(m.loadSessions, clearMessageAfter(2*time.Second))
+
+	case key.Matches(msg, keys.MoveWindow):
+		return m.startMoveWindow()
+
+	case key.Matches(msg, keys.Mark):
+		m.toggleMark()
+		return m, nil
+
+	case key.Matches(msg, keys.Yank):
+		return m, m.yankSwitchCommand()
+
+	case key.Matches(msg, keys.MessageLog):
+		m.mode = ModeLog
+		m.logOffset = 0
+		return m, tea.WindowSize()
+
+	case key.Matches(msg, keys.GoTo):
+		// Session numbers are only stable without a filter
+		m.mode = ModeGoTo
+		m.gotoInput = ""
+		if m.filter != "" {
+			m.filter = ""
+			m.rebuildItems()
+			m.updateScrollOffset()
+		}
+		return m, nil
+
+	case key.Matches(msg, keys.Settings):
+		m.mode = ModeSettings
+		m.settingsCursor = 0
+		return m, tea.WindowSize()
+
+	// Prefix key for number jumps (jump_prefix); the digit comes next
+	case m.filter == "" && m.config.JumpPrefix != "" && m.config.JumpPrefix != config.JumpPrefixAlt &&
+		msg.String() == m.config.JumpPrefix:
+		m.jumpPending = true
+		return m, nil
 
 	case msg.Type == tea.KeyBackspace:
 		if len(m.filter) > 0 {
@@ -519,33 +1217,325 @@ func (m *Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.rebuildItems()
 		}
 
+	case msg.Type == tea.KeyRunes && msg.Alt && len(msg.Runes) == 1:
+		// Alt+letter: move to the next session starting with that letter
+		m.jumpToLetter(msg.Runes[0])
+
 	case msg.Type == tea.KeyRunes:
 		// Add typed characters to filter
-		m.filter += string(msg.Runes)
+		m.filter += pendingPrefix + string(msg.Runes)
 		m.rebuildItems()
+		return m, tea.Batch(m.loadWindowsForFilterCmd(), m.autoSwitchTick())
 	}
 
 	return m, nil
 }
 
-func (m *Model) handleConfirmKillMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// jumpNumber returns the digit a key press stands for, ignoring Alt
+func jumpNumber(msg tea.KeyMsg) (int, bool) {
 	keys := ui.DefaultKeyMap
-
-	switch {
-	case key.Matches(msg, keys.Kill):
-		// Double C-x confirms the kill
-		return m.killCurrent()
-	case key.Matches(msg, keys.Cancel):
-		m.mode = ModeNormal
-		m.message = ""
-		m.killTarget = ""
+	msg.Alt = false
+	for num, binding := range []key.Binding{
+		keys.Jump0, keys.Jump1, keys.Jump2, keys.Jump3, keys.Jump4,
+		keys.Jump5, keys.Jump6, keys.Jump7, keys.Jump8, keys.Jump9,
+	} {
+		if key.Matches(msg, binding) {
+			return num, true
+		}
 	}
-
-	return m, nil
+	return 0, false
 }
 
-func (m *Model) handleCreateMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	keys := ui.DefaultKeyMap
+// jumpDigit returns the session number a key press jumps to under jump_prefix:
+// bare digits while no filter is typed (default), or M-<n> with "alt"
+// A prefix key is handled by handleNormalMode through jumpPending instead
+func (m *Model) jumpDigit(msg tea.KeyMsg) (int, bool) {
+	switch m.config.JumpPrefix {
+	case "":
+		if m.filter != "" || msg.Alt {
+			return 0, false
+		}
+	case config.JumpPrefixAlt:
+		if !msg.Alt {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	return jumpNumber(msg)
+}
+
+// jumpToLetter moves the cursor to the next visible session whose name starts
+// with r (case-insensitive), wrapping around so repeated presses cycle
+func (m *Model) jumpToLetter(r rune) {
+	prefix := strings.ToLower(string(r))
+	for offset := 1; offset <= len(m.items); offset++ {
+		i := (m.cursor + offset) % len(m.items)
+		item := m.items[i]
+		if item.Type != ItemTypeSession {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(m.sessions[item.SessionIndex].Name), prefix) {
+			m.cursor = i
+			m.updateScrollOffset()
+			return
+		}
+	}
+}
+
+func (m *Model) handleConfirmKillMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := ui.DefaultKeyMap
+
+	switch {
+	case key.Matches(msg, keys.Kill):
+		// Double C-x confirms the kill; uncommitted work in the directory takes a third
+		if m.killRemovePath != "" {
+			if m.killRemoveDirty > 0 && !m.killRemoveArmed {
+				m.killRemoveArmed = true
+				m.setError("Really DELETE %s with %d uncommitted changes? C-x again to confirm",
+					tildePath(m.killRemovePath), m.killRemoveDirty)
+				return m, nil
+			}
+			return m.killAndRemoveDir()
+		}
+		if len(m.selected) > 0 {
+			return m.killMarked()
+		}
+		return m.killCurrent()
+	case key.Matches(msg, keys.KillRemoveDir) && m.killRemovePath == "":
+		m.confirmKillRemoveDir()
+	case key.Matches(msg, keys.Cancel):
+		m.mode = ModeNormal
+		m.message = ""
+		m.killTarget = ""
+		m.resetKillRemove()
+	}
+
+	return m, nil
+}
+
+// resetKillRemove drops the directory queued for deletion by the kill prompt
+func (m *Model) resetKillRemove() {
+	m.killRemovePath = ""
+	m.killRemoveDirty = 0
+	m.killRemoveArmed = false
+}
+
+// carriedFilter returns the session filter to seed the picker and clone lists
+// with (carry_filter), without the @ path scope; "" when carry_filter is off
+func (m Model) carriedFilter() string {
+	if !m.config.CarryFilter {
+		return ""
+	}
+	return strings.TrimPrefix(m.filter, pathFilterPrefix)
+}
+
+// quit exits helm, first asking for confirmation with warn_on_quit when
+// sessions have uncommitted changes or are running watched commands
+func (m *Model) quit() (tea.Model, tea.Cmd) {
+	if !m.config.WarnOnQuit {
+		return m, tea.Quit
+	}
+	warning := m.quitWarning()
+	if warning == "" {
+		return m, tea.Quit
+	}
+	m.message = fmt.Sprintf("You have %s — quit anyway?", warning)
+	m.messageIsError = false
+	m.mode = ModeConfirmQuit
+	return m, nil
+}
+
+// quitWarning summarizes sessions worth a second look before quitting, from the
+// already loaded git statuses and watch_commands ("" if there are none)
+func (m Model) quitWarning() string {
+	dirty, watched := 0, 0
+	for _, s := range m.sessions {
+		if status, ok := m.gitStatuses[s.Name]; ok && status.Dirty > 0 {
+			dirty++
+		}
+		if s.Watched {
+			watched++
+		}
+	}
+
+	var parts []string
+	if dirty > 0 {
+		parts = append(parts, pluralize(dirty, "session")+" with uncommitted changes")
+	}
+	if watched > 0 {
+		parts = append(parts, pluralize(watched, "session")+" running watched commands")
+	}
+	return strings.Join(parts, " and ")
+}
+
+func (m *Model) handleConfirmQuitMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := ui.DefaultKeyMap
+
+	switch {
+	case key.Matches(msg, keys.Quit), msg.Type == tea.KeyEnter:
+		return m, tea.Quit
+	case key.Matches(msg, keys.Cancel):
+		m.mode = ModeNormal
+		m.message = ""
+	}
+
+	return m, nil
+}
+
+// handleConfirmClearClaudeMode wipes every Claude status file on confirmation,
+// for states stuck after a crashed hook (unlike cleanupClaudeStatusCmd, live sessions too)
+func (m *Model) handleConfirmClearClaudeMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := ui.DefaultKeyMap
+
+	switch {
+	case key.Matches(msg, keys.Kill), msg.Type == tea.KeyEnter:
+		m.mode = ModeNormal
+		removed, err := claude.ClearAll(m.config.CacheDir)
+		if err != nil {
+			m.setError("Failed to clear Claude statuses: %v", err)
+			return m, clearMessageAfter(3 * time.Second)
+		}
+		m.loadClaudeStatuses()
+		m.setMessage("Removed %s", pluralize(removed, "status file"))
+		return m, clearMessageAfter(2 * time.Second)
+	case key.Matches(msg, keys.Cancel):
+		m.mode = ModeNormal
+		m.message = ""
+	}
+
+	return m, nil
+}
+
+// startBroadcast opens the prompt for a command typed into every pane of the
+// selected session (the parent session for windows and panes)
+func (m *Model) startBroadcast() (tea.Model, tea.Cmd) {
+	if !m.isCursorValid() || m.items[m.cursor].Type == ItemTypeRecent {
+		return m, nil
+	}
+	m.broadcastTarget = m.sessions[m.items[m.cursor].SessionIndex].Name
+	m.broadcastCommand = ""
+	m.mode = ModeBroadcast
+	m.input.Reset()
+	m.input.CharLimit = 0
+	m.input.Focus()
+	return m, textinput.Blink
+}
+
+// handleBroadcastMode takes the command, then asks before sending it, since it
+// runs in every pane of the session at once (git pull, make, ...)
+func (m *Model) handleBroadcastMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := ui.DefaultKeyMap
+
+	if m.broadcastCommand != "" {
+		switch {
+		case key.Matches(msg, keys.Kill), msg.Type == tea.KeyEnter:
+			m.mode = ModeNormal
+			m.input.Blur()
+			sent, err := m.tmuxClient().SendKeysAll(m.broadcastTarget, m.broadcastCommand)
+			if err != nil {
+				m.setError("Failed to send to %s: %v", m.broadcastTarget, err)
+				return m, clearMessageAfter(3 * time.Second)
+			}
+			m.setMessage("Sent to %s in %s", pluralize(sent, "pane"), m.broadcastTarget)
+			return m, clearMessageAfter(2 * time.Second)
+		case key.Matches(msg, keys.Cancel):
+			// Back to editing the command
+			m.broadcastCommand = ""
+			m.message = ""
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, keys.Cancel):
+		m.mode = ModeNormal
+		m.input.Blur()
+		return m, nil
+	case msg.Type == tea.KeyEnter:
+		command := strings.TrimSpace(m.input.Value())
+		if command == "" {
+			return m, nil
+		}
+		m.broadcastCommand = command
+		m.message = fmt.Sprintf("Run %q in every pane of %s?", command, m.broadcastTarget)
+		m.messageIsError = false
+		return m, nil
+	case msg.Type == tea.KeyCtrlJ || msg.Type == tea.KeyCtrlK || msg.Type == tea.KeyCtrlX:
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// respawnDeadPanes restarts every dead pane (remain-on-exit) of the selected
+// session, or of the parent session for windows and panes, in the background
+func (m *Model) respawnDeadPanes() (tea.Model, tea.Cmd) {
+	if !m.isCursorValid() || m.items[m.cursor].Type == ItemTypeRecent {
+		return m, nil
+	}
+	session := m.sessions[m.items[m.cursor].SessionIndex]
+	if session.DeadPanes == 0 {
+		m.setMessage("No dead panes in %s", session.Name)
+		return m, clearMessageAfter(2 * time.Second)
+	}
+
+	client, name := m.tmuxClient(), session.Name
+	return m, func() tea.Msg {
+		respawned, err := client.RespawnDeadPanes(name)
+		return respawnedMsg{session: name, respawned: respawned, err: err}
+	}
+}
+
+// maxGoToDigits limits the go-to prompt's input length
+const maxGoToDigits = 4
+
+// handleGoToMode collects a session number; the cursor follows while typing
+// and Enter switches to it like the 0-9 keys (but never to a window)
+func (m *Model) handleGoToMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := ui.DefaultKeyMap
+
+	switch {
+	case key.Matches(msg, keys.Cancel), key.Matches(msg, keys.GoTo):
+		m.mode = ModeNormal
+		m.gotoInput = ""
+
+	case key.Matches(msg, keys.Quit):
+		return m, tea.Quit
+
+	case msg.Type == tea.KeyEnter:
+		num, err := strconv.Atoi(m.gotoInput)
+		m.mode = ModeNormal
+		m.gotoInput = ""
+		if err != nil {
+			return m, nil
+		}
+		return m.jumpToSession(num)
+
+	case msg.Type == tea.KeyBackspace:
+		if m.gotoInput != "" {
+			m.gotoInput = m.gotoInput[:len(m.gotoInput)-1]
+		}
+
+	case msg.Type == tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if r >= '0' && r <= '9' && len(m.gotoInput) < maxGoToDigits {
+				m.gotoInput += string(r)
+			}
+		}
+		if num, err := strconv.Atoi(m.gotoInput); err == nil && num < m.shownSessionCount() {
+			m.restoreCursor(m.sessions[num].Name)
+			m.updateScrollOffset()
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) handleCreateMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := ui.DefaultKeyMap
 
 	switch {
 	case key.Matches(msg, keys.Cancel):
@@ -554,12 +1544,21 @@ func (m *Model) handleCreateMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case msg.Type == tea.KeyEnter:
-		name := strings.TrimSpace(m.input.Value())
+		name, command := splitCreateInput(m.input.Value())
 		if name == "" {
 			m.setError("Session name cannot be empty")
 			return m, nil
 		}
-		return m.createSession(name)
+		m.recordCreateHistory(strings.TrimSpace(m.input.Value()))
+		return m.createSession(name, command)
+
+	case key.Matches(msg, keys.Up):
+		m.browseCreateHistory(1)
+		return m, nil
+
+	case key.Matches(msg, keys.Down):
+		m.browseCreateHistory(-1)
+		return m, nil
 	}
 
 	// Ignore ctrl key combinations - only pass regular typing to input
@@ -573,9 +1572,37 @@ func (m *Model) handleCreateMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 	m.input, cmd = m.input.Update(msg)
+	m.checkCreateName()
 	return m, cmd
 }
 
+// browseCreateHistory steps through the create history like a shell: step 1
+// goes to an older entry, -1 to a newer one and finally back to the typed input
+func (m *Model) browseCreateHistory(step int) {
+	pos := m.createHistoryPos + step
+	if pos >= len(m.createHistory) || pos < -1 {
+		return
+	}
+	if m.createHistoryPos == -1 {
+		m.createDraft = m.input.Value()
+	}
+	m.createHistoryPos = pos
+	if pos == -1 {
+		m.input.SetValue(m.createDraft)
+	} else {
+		m.input.SetValue(m.createHistory[pos])
+	}
+	m.input.CursorEnd()
+	m.checkCreateName()
+}
+
+// checkCreateName flags a create input that names an existing session, checked on
+// every keystroke (has-session is cheap) so Enter switching instead isn't a surprise
+func (m *Model) checkCreateName() {
+	name, _ := splitCreateInput(m.input.Value())
+	m.createExists = name != "" && m.tmuxClient().SessionExists(sanitizeSessionName(name))
+}
+
 func (m *Model) handleCreatePathMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	keys := ui.DefaultKeyMap
 
@@ -704,8 +1731,8 @@ func (m *Model) createSessionAtPath(fullPath string) (tea.Model, tea.Cmd) {
 	m.pathInput.Blur()
 
 	// Check if session already exists - if so, just switch to it
-	if tmux.SessionExists(sessionName) {
-		if err := tmux.SwitchClient(sessionName); err != nil {
+	if m.tmuxClient().SessionExists(sessionName) {
+		if err := m.switchClient(sessionName); err != nil {
 			m.setError("Failed to switch: %v", err)
 			return m, m.loadSessions
 		}
@@ -713,7 +1740,7 @@ func (m *Model) createSessionAtPath(fullPath string) (tea.Model, tea.Cmd) {
 	}
 
 	// Create the session
-	if err := tmux.CreateSession(sessionName, fullPath); err != nil {
+	if err := m.tmuxClient().CreateSession(sessionName, fullPath, m.config.SessionShell); err != nil {
 		m.setError("Error: %v", err)
 		m.mode = ModeNormal
 		return m, nil
@@ -723,7 +1750,7 @@ func (m *Model) createSessionAtPath(fullPath string) (tea.Model, tea.Cmd) {
 	m.applyLayout(sessionName, fullPath)
 
 	// Switch to the new session
-	if err := tmux.SwitchClient(sessionName); err != nil {
+	if err := m.switchClient(sessionName); err != nil {
 		m.setError("Created but failed to switch: %v", err)
 		return m, m.loadSessions
 	}
@@ -770,6 +1797,12 @@ func (m *Model) handlePickDirectoryMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, keys.Kill):
 		return m.confirmRemoveFolder()
 
+	case key.Matches(msg, keys.Refresh):
+		// Re-scan directories, keeping the current filter
+		m.projectList.SetItems(m.scanProjectDirectories())
+		m.setMessage("Refreshed")
+		return m, clearMessageAfter(2 * time.Second)
+
 	case key.Matches(msg, keys.AddBookmark):
 		// Add selected project to bookmarks
 		if selected, ok := m.projectList.SelectedItem(); ok {
@@ -808,7 +1841,7 @@ func (m *Model) handleCloneRepoMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, keys.Select):
 			// Apply layout and switch to the session
 			m.applyLayout(m.cloneSuccessSession, m.cloneSuccessPath)
-			if err := tmux.SwitchClient(m.cloneSuccessSession); err != nil {
+			if err := m.switchClient(m.cloneSuccessSession); err != nil {
 				m.setError("Created but failed to switch: %v", err)
 				m.mode = ModeNormal
 				m.cloneSuccess = false
@@ -828,7 +1861,7 @@ func (m *Model) handleCloneRepoMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, keys.Cancel):
 		// If loading or cloning, just cancel and go back
-		if m.cloneLoading || m.cloneCloning {
+		if m.cloneCloning || (m.cloneLoading && !m.cloneURLMode) {
 			m.mode = ModeNormal
 			m.cloneLoading = false
 			m.cloneCloning = false
@@ -849,6 +1882,9 @@ func (m *Model) handleCloneRepoMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.mode = ModeNormal
 		return m, nil
 
+	case key.Matches(msg, keys.CloneURL):
+		m.toggleCloneURLMode()
+
 	case key.Matches(msg, keys.Up):
 		m.cloneList.MoveCursor(-1)
 
@@ -856,6 +1892,12 @@ func (m *Model) handleCloneRepoMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.cloneList.MoveCursor(1)
 
 	case key.Matches(msg, keys.Select):
+		if m.cloneURLMode {
+			if url := strings.TrimSpace(m.cloneList.Filter()); url != "" && !m.cloneCloning && m.cloneError == "" {
+				return m.cloneFromURL(url)
+			}
+			return m, nil
+		}
 		if selected, ok := m.cloneList.SelectedItem(); ok && !m.cloneLoading && !m.cloneCloning && m.cloneError == "" {
 			return m.cloneSelectedRepo(selected)
 		}
@@ -865,12 +1907,12 @@ func (m *Model) handleCloneRepoMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case msg.Type == tea.KeyBackspace:
 		filter := m.cloneList.Filter()
-		if len(filter) > 0 && !m.cloneLoading && !m.cloneCloning {
+		if len(filter) > 0 && (!m.cloneLoading || m.cloneURLMode) && !m.cloneCloning {
 			m.cloneList.SetFilter(filter[:len(filter)-1])
 		}
 
 	case msg.Type == tea.KeyRunes:
-		if !m.cloneLoading && !m.cloneCloning && m.cloneError == "" {
+		if (!m.cloneLoading || m.cloneURLMode) && !m.cloneCloning && m.cloneError == "" {
 			m.cloneList.SetFilter(m.cloneList.Filter() + string(msg.Runes))
 		}
 	}
@@ -878,89 +1920,369 @@ func (m *Model) handleCloneRepoMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *Model) handleBookmarksMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// toggleCloneURLMode switches the clone prompt between filtering the repo list and
+// taking a pasted git URL, e.g. for a repo outside the account's list
+// The repo list may still be loading; it's there when switching back
+func (m *Model) toggleCloneURLMode() {
+	if m.cloneCloning || m.cloneNoGh {
+		return
+	}
+	m.cloneURLMode = !m.cloneURLMode
+	m.cloneList.SetFilter("")
+	if m.cloneURLMode {
+		// "All repositories are already cloned" or a gh failure doesn't apply to a URL
+		m.cloneError = ""
+	} else if !m.cloneLoading && len(m.cloneList.Items()) == 0 {
+		m.cloneError = "All repositories are already cloned!"
+	}
+}
+
+func (m *Model) handleLogMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	keys := ui.DefaultKeyMap
 
 	switch {
-	case key.Matches(msg, keys.Cancel):
-		// If filter is active, clear it first
-		if m.bookmarkList.Filter() != "" {
-			m.bookmarkList.SetFilter("")
-			return m, nil
-		}
-		// Otherwise return to normal mode
+	case key.Matches(msg, keys.Cancel), key.Matches(msg, keys.MessageLog):
 		m.mode = ModeNormal
-		return m, nil
 
 	case key.Matches(msg, keys.Up):
-		m.bookmarkList.MoveCursor(-1)
+		if m.logOffset > 0 {
+			m.logOffset--
+		}
 
 	case key.Matches(msg, keys.Down):
-		m.bookmarkList.MoveCursor(1)
-
-	case key.Matches(msg, keys.Expand):
-		// Expand bookmark if it has a session
-		if selected, ok := m.bookmarkList.SelectedItem(); ok {
-			sessionName := m.extractSessionName(selected.Path)
-			if session := m.findSessionByName(sessionName); session != nil {
-				m.bookmarkExpanded[selected.Path] = true
-			}
+		if m.logOffset < len(m.messages)-m.projectMaxVisibleItems() {
+			m.logOffset++
 		}
 
-	case key.Matches(msg, keys.Collapse):
-		// Collapse bookmark
-		if selected, ok := m.bookmarkList.SelectedItem(); ok {
-			m.bookmarkExpanded[selected.Path] = false
+	case key.Matches(msg, keys.Quit):
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+func (m *Model) handleSettingsMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := ui.DefaultKeyMap
+
+	switch {
+	case key.Matches(msg, keys.Cancel), key.Matches(msg, keys.Settings):
+		m.mode = ModeNormal
+
+	case key.Matches(msg, keys.Up):
+		if m.settingsCursor > 0 {
+			m.settingsCursor--
 		}
 
-	case key.Matches(msg, keys.Select):
-		if selected, ok := m.bookmarkList.SelectedItem(); ok {
-			return m.openBookmark(selected)
+	case key.Matches(msg, keys.Down):
+		if m.settingsCursor < len(config.Settings)-1 {
+			m.settingsCursor++
 		}
 
-	case key.Matches(msg, keys.PickDirectory):
-		// C-p moves bookmark up in bookmarks mode
-		return m.moveBookmark(-1)
+	case key.Matches(msg, keys.Select):
+		return m, m.changeSetting(0)
 
-	case key.Matches(msg, keys.Create):
-		// C-n moves bookmark down in bookmarks mode
-		return m.moveBookmark(1)
+	case key.Matches(msg, keys.Expand):
+		return m, m.changeSetting(1)
 
-	case key.Matches(msg, keys.Kill):
-		return m.removeBookmark()
+	case key.Matches(msg, keys.Collapse):
+		return m, m.changeSetting(-1)
 
-	case key.Matches(msg, keys.AddBookmark):
-		// Open project picker to add a new bookmark
-		m.mode = ModePickDirectory
-		m.returnToBookmarks = true
-		m.projectList.Reset()
-		m.projectList.SetItems(m.scanProjectDirectories())
-		return m, tea.WindowSize()
+	case key.Matches(msg, keys.EditConfig):
+		return m.editConfig()
 
 	case key.Matches(msg, keys.Quit):
 		return m, tea.Quit
-
-	case msg.Type == tea.KeyBackspace:
-		filter := m.bookmarkList.Filter()
-		if len(filter) > 0 {
-			m.bookmarkList.SetFilter(filter[:len(filter)-1])
-		}
-
-	case msg.Type == tea.KeyRunes:
-		m.bookmarkList.SetFilter(m.bookmarkList.Filter() + string(msg.Runes))
 	}
 
 	return m, nil
 }
 
-// addPathToBookmarks adds a path to bookmarks
-func (m *Model) addPathToBookmarks(path string) (tea.Model, tea.Cmd) {
-	// Check if already bookmarked
-	for _, b := range m.config.Bookmarks {
-		if b.Path == path {
-			m.setError("Already bookmarked")
-			return m, nil
-		}
+// changeSetting toggles the selected boolean setting, or steps an int setting by delta,
+// then saves it and applies it to the running UI where possible
+func (m *Model) changeSetting(delta int) tea.Cmd {
+	setting := config.Settings[m.settingsCursor]
+	current := m.config.SettingValue(setting.Key)
+
+	var value string
+	if b, err := strconv.ParseBool(current); err == nil {
+		value = strconv.FormatBool(!b)
+	} else if n, err := strconv.Atoi(current); err == nil && delta != 0 {
+		value = strconv.Itoa(max(setting.Min, n+delta))
+	} else {
+		return nil
+	}
+	if value == current {
+		return nil
+	}
+
+	if err := m.config.SetSetting(setting.Key, value); err != nil {
+		m.setError("Error: %v", err)
+		return nil
+	}
+	if err := config.SaveSetting(setting.Key, value); err != nil {
+		m.setError("Failed to save settings: %v", err)
+		return nil
+	}
+	return m.applySetting(setting.Key)
+}
+
+// toggleSetting flips a boolean setting for this run only (F6/F7), unlike the
+// settings view, which saves it
+func (m *Model) toggleSetting(key, label string) tea.Cmd {
+	on := m.config.SettingValue(key) != "true"
+	if err := m.config.SetSetting(key, strconv.FormatBool(on)); err != nil {
+		m.setError("Error: %v", err)
+		return nil
+	}
+	cmd := m.applySetting(key)
+	m.calculateColumnWidths()
+	if on {
+		m.setMessage("%s on", label)
+	} else {
+		m.setMessage("%s off", label)
+	}
+	return tea.Batch(cmd, clearMessageAfter(2*time.Second))
+}
+
+// applySetting makes a changed setting take effect without restarting helm
+// Settings that only matter on the next start or action need nothing here
+func (m *Model) applySetting(key string) tea.Cmd {
+	switch key {
+	case "git_status_enabled":
+		if !m.gitStatusEnabled() {
+			m.maxGitStatusWidth = 0
+			return nil
+		}
+		m.maxGitStatusWidth = ui.GitStatusColumnWidth
+		return m.fetchGitStatusesCmd()
+	case "claude_status_enabled":
+		m.loadClaudeStatuses()
+	case "compact":
+		m.compact = m.config.Compact
+	case "env_versions_enabled":
+		m.envReader, m.envVersions = nil, nil
+		if m.config.EnvVersionsEnabled {
+			m.envReader = project.NewEnvReader(m.config.EnvFiles)
+		}
+	case "project_icons_enabled":
+		m.projectDetector = nil
+		if m.config.ProjectIconsEnabled {
+			m.projectDetector = project.NewDetector(m.config.ProjectTypes)
+			return m.resolveSessionPathsCmd()
+		}
+	case "show_current", "include_current":
+		m.currentInfo = nil
+		return m.loadSessions
+	case "max_sessions":
+		m.rebuildItems()
+	case "project_depth":
+		// The picker filter matches the displayed path, which depends on the depth
+		m.projectList = newProjectList(m.config)
+		if m.config.NameDisplay == config.NameDisplayPath {
+			// So do session names shown as paths; the old width may be too wide
+			m.maxNameWidth = 0
+			m.calculateColumnWidths()
+		}
+	}
+	return nil
+}
+
+// startMoveWindow opens the target session picker for the selected window
+func (m *Model) startMoveWindow() (tea.Model, tea.Cmd) {
+	if m.denyReadOnly() {
+		return m, clearMessageAfter(readOnlyMessageDuration)
+	}
+	if !m.isCursorValid() {
+		return m, nil
+	}
+	item := m.items[m.cursor]
+	if item.Type != ItemTypeWindow {
+		m.setError("Select a window to move")
+		return m, nil
+	}
+
+	session := m.sessions[item.SessionIndex]
+	m.moveSourceName = session.Name
+	m.moveSourceWindow = session.Windows[item.WindowIndex].Index
+
+	// Candidates: the current session first, then every other listed session
+	var targets []string
+	if m.currentSession != "" {
+		targets = append(targets, m.currentSession)
+	}
+	for _, s := range m.sessions {
+		if s.Name != session.Name && s.Name != m.currentSession {
+			targets = append(targets, s.Name)
+		}
+	}
+	if len(targets) == 0 {
+		m.setError("No other sessions to move to")
+		return m, nil
+	}
+
+	m.mode = ModeMoveWindow
+	m.moveList.Reset()
+	m.moveList.SetItems(targets)
+	return m, tea.WindowSize()
+}
+
+func (m *Model) handleMoveWindowMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := ui.DefaultKeyMap
+
+	switch {
+	case key.Matches(msg, keys.Cancel):
+		if m.moveList.Filter() != "" {
+			m.moveList.SetFilter("")
+			return m, nil
+		}
+		m.mode = ModeNormal
+		return m, nil
+
+	case key.Matches(msg, keys.Up):
+		m.moveList.MoveCursor(-1)
+
+	case key.Matches(msg, keys.Down):
+		m.moveList.MoveCursor(1)
+
+	case key.Matches(msg, keys.Select):
+		if target, ok := m.moveList.SelectedItem(); ok {
+			return m.moveWindowTo(target, false)
+		}
+
+	case key.Matches(msg, keys.LinkWindow):
+		if target, ok := m.moveList.SelectedItem(); ok {
+			return m.moveWindowTo(target, true)
+		}
+
+	case key.Matches(msg, keys.Quit):
+		return m, tea.Quit
+
+	case msg.Type == tea.KeyBackspace:
+		filter := m.moveList.Filter()
+		if len(filter) > 0 {
+			m.moveList.SetFilter(filter[:len(filter)-1])
+		}
+
+	case msg.Type == tea.KeyRunes:
+		m.moveList.SetFilter(m.moveList.Filter() + string(msg.Runes))
+	}
+
+	return m, nil
+}
+
+// moveWindowTo moves (or links) the pending window into the target session
+// tmux assigns the next free index in the target, so index collisions can't occur
+func (m *Model) moveWindowTo(target string, link bool) (tea.Model, tea.Cmd) {
+	var err error
+	verb := "Moved"
+	if link {
+		verb = "Linked"
+		err = m.tmuxClient().LinkWindow(m.moveSourceName, m.moveSourceWindow, target)
+	} else {
+		err = m.tmuxClient().MoveWindow(m.moveSourceName, m.moveSourceWindow, target)
+	}
+
+	m.mode = ModeNormal
+	if err != nil {
+		m.setError("Error: %v", err)
+		return m, nil
+	}
+
+	// Force windows to reload for both sessions
+	for i := range m.sessions {
+		if m.sessions[i].Name == m.moveSourceName || m.sessions[i].Name == target {
+			m.sessions[i].Windows = nil
+			m.sessions[i].Expanded = false
+		}
+	}
+
+	m.setMessage("%s window %s:%d to %s", verb, m.moveSourceName, m.moveSourceWindow, target)
+	return m, tea.Batch(m.loadSessions, clearMessageAfter(5*time.Second))
+}
+
+func (m *Model) handleBookmarksMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := ui.DefaultKeyMap
+
+	switch {
+	case key.Matches(msg, keys.Cancel):
+		// If filter is active, clear it first
+		if m.bookmarkList.Filter() != "" {
+			m.bookmarkList.SetFilter("")
+			return m, nil
+		}
+		// Otherwise return to normal mode
+		m.mode = ModeNormal
+		return m, nil
+
+	case key.Matches(msg, keys.Up):
+		m.bookmarkList.MoveCursor(-1)
+
+	case key.Matches(msg, keys.Down):
+		m.bookmarkList.MoveCursor(1)
+
+	case key.Matches(msg, keys.Expand):
+		// Expand bookmark if it has a session
+		if selected, ok := m.bookmarkList.SelectedItem(); ok {
+			sessionName := m.extractSessionName(selected.Path)
+			if session := m.findSessionByName(sessionName); session != nil {
+				m.bookmarkExpanded[selected.Path] = true
+			}
+		}
+
+	case key.Matches(msg, keys.Collapse):
+		// Collapse bookmark
+		if selected, ok := m.bookmarkList.SelectedItem(); ok {
+			m.bookmarkExpanded[selected.Path] = false
+		}
+
+	case key.Matches(msg, keys.Select):
+		if selected, ok := m.bookmarkList.SelectedItem(); ok {
+			return m.openBookmark(selected)
+		}
+
+	case key.Matches(msg, keys.PickDirectory):
+		// C-p moves bookmark up in bookmarks mode
+		return m.moveBookmark(-1)
+
+	case key.Matches(msg, keys.Create):
+		// C-n moves bookmark down in bookmarks mode
+		return m.moveBookmark(1)
+
+	case key.Matches(msg, keys.Kill):
+		return m.removeBookmark()
+
+	case key.Matches(msg, keys.AddBookmark):
+		// Open project picker to add a new bookmark
+		m.mode = ModePickDirectory
+		m.returnToBookmarks = true
+		m.projectList.Reset()
+		m.projectList.SetItems(m.scanProjectDirectories())
+		return m, tea.WindowSize()
+
+	case key.Matches(msg, keys.Quit):
+		return m, tea.Quit
+
+	case msg.Type == tea.KeyBackspace:
+		filter := m.bookmarkList.Filter()
+		if len(filter) > 0 {
+			m.bookmarkList.SetFilter(filter[:len(filter)-1])
+		}
+
+	case msg.Type == tea.KeyRunes:
+		m.bookmarkList.SetFilter(m.bookmarkList.Filter() + string(msg.Runes))
+	}
+
+	return m, nil
+}
+
+// addPathToBookmarks adds a path to bookmarks
+func (m *Model) addPathToBookmarks(path string) (tea.Model, tea.Cmd) {
+	// Check if already bookmarked
+	for _, b := range m.config.Bookmarks {
+		if b.Path == path {
+			m.setError("Already bookmarked")
+			return m, nil
+		}
 	}
 
 	// Add bookmark
@@ -991,9 +2313,8 @@ func (m *Model) addSelectedToBookmarks() (tea.Model, tea.Cmd) {
 	}
 
 	session := m.sessions[item.SessionIndex]
-	// Get session path from tmux
-	path, err := git.GetSessionPath(session.Name)
-	if err != nil || path == "" {
+	path, ok := m.sessionPath(session.Name)
+	if !ok {
 		// Fallback: assume it's in one of the project dirs
 		for _, dir := range m.config.ProjectDirs {
 			possiblePath := filepath.Join(dir, session.Name)
@@ -1037,8 +2358,8 @@ func (m *Model) openBookmark(bookmark config.Bookmark) (tea.Model, tea.Cmd) {
 	sessionName := m.extractSessionName(bookmark.Path)
 
 	// Create session if it doesn't exist
-	if !tmux.SessionExists(sessionName) {
-		if err := tmux.CreateSession(sessionName, bookmark.Path); err != nil {
+	if !m.tmuxClient().SessionExists(sessionName) {
+		if err := m.tmuxClient().CreateSession(sessionName, bookmark.Path, m.config.SessionShell); err != nil {
 			m.setError("Failed to create session: %v", err)
 			return m, nil
 		}
@@ -1048,7 +2369,7 @@ func (m *Model) openBookmark(bookmark config.Bookmark) (tea.Model, tea.Cmd) {
 	}
 
 	// Switch to the session
-	if err := tmux.SwitchClient(sessionName); err != nil {
+	if err := m.switchClient(sessionName); err != nil {
 		m.setError("Failed to switch to session: %v", err)
 		return m, nil
 	}
@@ -1083,6 +2404,9 @@ func (m *Model) moveBookmark(delta int) (tea.Model, tea.Cmd) {
 
 // removeBookmark removes the selected bookmark
 func (m *Model) removeBookmark() (tea.Model, tea.Cmd) {
+	if m.denyReadOnly() {
+		return m, clearMessageAfter(readOnlyMessageDuration)
+	}
 	cursor := m.bookmarkList.Cursor()
 	if cursor < 0 || cursor >= len(m.config.Bookmarks) {
 		return m, nil
@@ -1111,6 +2435,7 @@ func (m *Model) cloneSelectedRepo(selected string) (tea.Model, tea.Cmd) {
 
 	destPath := filepath.Join(m.cloneBasePath, selected)
 	sessionName := sanitizeSessionName(selected)
+	shell, client := m.config.SessionShell, m.tmuxClient()
 
 	return m, func() tea.Msg {
 		if err := github.CloneRepo(selected, destPath); err != nil {
@@ -1118,7 +2443,40 @@ func (m *Model) cloneSelectedRepo(selected string) (tea.Model, tea.Cmd) {
 		}
 
 		// Create tmux session
-		if err := tmux.CreateSession(sessionName, destPath); err != nil {
+		if err := client.CreateSession(sessionName, destPath, shell); err != nil {
+			return cloneErrorMsg{err: fmt.Errorf("cloned but failed to create session: %w", err)}
+		}
+
+		return cloneSuccessMsg{
+			repoPath:    destPath,
+			sessionName: sessionName,
+		}
+	}
+}
+
+// cloneFromURL clones a typed git URL with plain git (Tab in clone mode, or when gh is missing)
+// The destination is <clone base>/<owner>/<repo>, derived from the URL
+func (m *Model) cloneFromURL(url string) (tea.Model, tea.Cmd) {
+	ownerRepo := github.ParseGitURL(url)
+	if ownerRepo == "" {
+		m.cloneError = fmt.Sprintf("Could not derive owner/repo from %s", url)
+		return m, nil
+	}
+
+	m.cloneCloning = true
+	m.cloneCloningRepo = ownerRepo
+
+	destPath := filepath.Join(m.cloneBasePath, ownerRepo)
+	sessionName := sanitizeSessionName(ownerRepo)
+	shell, client := m.config.SessionShell, m.tmuxClient()
+
+	return m, func() tea.Msg {
+		if err := github.CloneURL(url, destPath); err != nil {
+			return cloneErrorMsg{err: err}
+		}
+
+		// Create tmux session
+		if err := client.CreateSession(sessionName, destPath, shell); err != nil {
 			return cloneErrorMsg{err: fmt.Errorf("cloned but failed to create session: %w", err)}
 		}
 
@@ -1132,42 +2490,61 @@ func (m *Model) cloneSelectedRepo(selected string) (tea.Model, tea.Cmd) {
 // fetchAvailableReposCmd fetches repos from GitHub
 func (m *Model) fetchAvailableReposCmd() tea.Cmd {
 	basePath := m.cloneBasePath
+	withDetails := m.config.CloneDetailsEnabled
 	return func() tea.Msg {
+		// Without gh, fall back to cloning a typed URL
+		if !github.GhInstalled() {
+			return cloneNoGhMsg{}
+		}
+
 		// Check gh CLI
 		if err := github.CheckGhCli(); err != nil {
 			return cloneErrorMsg{err: err}
 		}
 
 		// Fetch available repos
-		available, err := github.FetchAvailableRepos()
+		repos, err := github.FetchAvailableRepos(withDetails)
 		if err != nil {
 			return cloneErrorMsg{err: err}
 		}
 
+		available := make([]string, len(repos))
+		var details map[string]github.Repo
+		if withDetails {
+			details = make(map[string]github.Repo, len(repos))
+		}
+		for i, r := range repos {
+			available[i] = r.FullName
+			if details != nil {
+				details[r.FullName] = r
+			}
+		}
+
 		// Get already cloned
 		cloned, _ := config.ListClonedRepos(basePath)
 
 		// Filter out cloned
 		uncloned := config.FilterUncloned(available, cloned)
 
-		return cloneReposLoadedMsg{repos: uncloned}
+		return cloneReposLoadedMsg{repos: uncloned, details: details}
 	}
 }
 
 func (m *Model) createSessionFromDir(fullPath string) (tea.Model, tea.Cmd) {
 	// Extract session name from full path (last N components based on depth)
 	name := m.extractSessionName(fullPath)
+	m.recordRecentProject(fullPath)
 
 	// Check if session already exists - if so, just switch to it
-	if tmux.SessionExists(name) {
-		if err := tmux.SwitchClient(name); err != nil {
+	if m.tmuxClient().SessionExists(name) {
+		if err := m.switchClient(name); err != nil {
 			m.setError("Failed to switch: %v", err)
 			return m, m.loadSessions
 		}
 		return m, tea.Quit
 	}
 
-	if err := tmux.CreateSession(name, fullPath); err != nil {
+	if err := m.tmuxClient().CreateSession(name, fullPath, m.config.SessionShell); err != nil {
 		m.setError("Error: %v", err)
 		m.mode = ModeNormal
 		return m, nil
@@ -1177,7 +2554,7 @@ func (m *Model) createSessionFromDir(fullPath string) (tea.Model, tea.Cmd) {
 	m.applyLayout(name, fullPath)
 
 	// Switch to the new session
-	if err := tmux.SwitchClient(name); err != nil {
+	if err := m.switchClient(name); err != nil {
 		m.setError("Created but failed to switch: %v", err)
 		return m, m.loadSessions
 	}
@@ -1203,8 +2580,8 @@ func (m *Model) createSessionWithNewFolder(basePath, sessionName string) (tea.Mo
 	m.pendingSessionName = ""
 
 	// Check if session already exists - if so, just switch to it
-	if tmux.SessionExists(sessionName) {
-		if err := tmux.SwitchClient(sessionName); err != nil {
+	if m.tmuxClient().SessionExists(sessionName) {
+		if err := m.switchClient(sessionName); err != nil {
 			m.setError("Failed to switch: %v", err)
 			return m, m.loadSessions
 		}
@@ -1212,7 +2589,7 @@ func (m *Model) createSessionWithNewFolder(basePath, sessionName string) (tea.Mo
 	}
 
 	// Create the session
-	if err := tmux.CreateSession(sessionName, fullPath); err != nil {
+	if err := m.tmuxClient().CreateSession(sessionName, fullPath, m.config.SessionShell); err != nil {
 		m.setError("Error: %v", err)
 		m.mode = ModeNormal
 		return m, nil
@@ -1222,7 +2599,7 @@ func (m *Model) createSessionWithNewFolder(basePath, sessionName string) (tea.Mo
 	m.applyLayout(sessionName, fullPath)
 
 	// Switch to the new session
-	if err := tmux.SwitchClient(sessionName); err != nil {
+	if err := m.switchClient(sessionName); err != nil {
 		m.setError("Created but failed to switch: %v", err)
 		return m, m.loadSessions
 	}
@@ -1243,16 +2620,60 @@ func (m *Model) extractSessionName(fullPath string) string {
 }
 
 // extractDisplayPath extracts a display path from a full path
-// Uses the last N path components based on ProjectDepth config
+// Rendering follows the picker_display config (see displayPath)
 func (m *Model) extractDisplayPath(fullPath string) string {
+	return displayPath(fullPath, m.config)
+}
+
+// displayPath renders fullPath for the directory picker: the last ProjectDepth
+// components, the path below its project_dirs root (relative) or the whole path (full)
+func displayPath(fullPath string, cfg config.Config) string {
+	switch cfg.PickerDisplay {
+	case config.PickerDisplayFull:
+		return tildePath(fullPath)
+	case config.PickerDisplayRelative:
+		if root := projectRoot(fullPath, cfg.ProjectDirs); root != "" {
+			return filepath.ToSlash(strings.TrimPrefix(fullPath, root+string(filepath.Separator)))
+		}
+	}
+
 	parts := strings.Split(fullPath, string(filepath.Separator))
-	depth := m.config.ProjectDepth
+	depth := cfg.ProjectDepth
 	if depth > len(parts) {
 		depth = len(parts)
 	}
 	return strings.Join(parts[len(parts)-depth:], "/")
 }
 
+// newProjectList creates the directory picker list, filtering on the displayed path
+func newProjectList(cfg config.Config) *ui.ScrollList[string] {
+	return ui.NewScrollList(func(fullPath string, filter string) bool {
+		return strings.Contains(strings.ToLower(displayPath(fullPath, cfg)), filter)
+	})
+}
+
+// projectRootLabel returns the base name of the project_dirs entry fullPath lives under
+func (m *Model) projectRootLabel(fullPath string) string {
+	root := projectRoot(fullPath, m.config.ProjectDirs)
+	if root == "" {
+		return ""
+	}
+	return filepath.Base(root)
+}
+
+// projectRoot returns the project_dirs entry fullPath lives under ("" if none)
+// The longest matching root wins so nested roots resolve to the most specific one
+func projectRoot(fullPath string, roots []string) string {
+	best := ""
+	for _, root := range roots {
+		root = filepath.Clean(root)
+		if strings.HasPrefix(fullPath, root+string(filepath.Separator)) && len(root) > len(best) {
+			best = root
+		}
+	}
+	return best
+}
+
 // findSessionByName finds a session by its name, returns nil if not found
 func (m *Model) findSessionByName(name string) *tmux.Session {
 	for i := range m.sessions {
@@ -1322,8 +2743,8 @@ func (m *Model) walkAtDepth(baseDir, currentPath string, remainingDepth int, dir
 		if !entry.IsDir() {
 			continue
 		}
-		// Skip hidden directories
-		if strings.HasPrefix(entry.Name(), ".") {
+		// Skip hidden directories unless include_hidden / hidden_dirs lets them in
+		if strings.HasPrefix(entry.Name(), ".") && !m.config.ScanHidden(entry.Name()) {
 			continue
 		}
 
@@ -1340,35 +2761,42 @@ func (m *Model) walkAtDepth(baseDir, currentPath string, remainingDepth int, dir
 
 func (m *Model) handleJump(num int) (tea.Model, tea.Cmd) {
 	// Check if we're inside an expanded session - numbers switch to windows
-	if m.cursor >= 0 && m.cursor < len(m.items) {
+	if m.isCursorValid() && m.items[m.cursor].Type != ItemTypeRecent {
 		item := m.items[m.cursor]
 		session := &m.sessions[item.SessionIndex]
 
-		if session.Expanded {
-			// Jump to window number within this session
-			for _, w := range session.Windows {
-				if w.Index == num {
-					target := fmt.Sprintf("%s:%d", session.Name, w.Index)
-					if err := tmux.SwitchClient(target); err != nil {
-						m.setError("Error: %v", err)
-						return m, nil
-					}
-					return m, tea.Quit
-				}
+		// Numbers pick the Nth window as listed: tmux indices can start at
+		// base-index 1 and have gaps, so they don't line up with the keys
+		if session.Expanded && num >= 1 && num <= len(session.Windows) {
+			target := fmt.Sprintf("%s:%d", session.Name, session.Windows[num-1].Index)
+			if err := m.switchClient(target); err != nil {
+				m.setError("Error: %v", err)
+				return m, nil
 			}
+			return m, tea.Quit
 		}
 	}
 
+	return m.jumpToSession(num)
+}
+
+// jumpToSession switches to the session (or opens the recent project) labeled num
+func (m *Model) jumpToSession(num int) (tea.Model, tea.Cmd) {
 	// Session labels: 0, 1, 2... map to session indices 0, 1, 2...
-	if num >= 0 && num < len(m.sessions) {
+	if num >= 0 && num < m.shownSessionCount() {
 		session := m.sessions[num]
-		if err := tmux.SwitchClient(session.Name); err != nil {
+		if err := m.switchClient(m.sessionTarget(session.Name)); err != nil {
 			m.setError("Error: %v", err)
 			return m, nil
 		}
 		return m, tea.Quit
 	}
 
+	// Recent project labels continue after the sessions
+	if i := num - m.shownSessionCount(); i >= 0 && i < len(m.recentRows) {
+		return m.createSessionFromDir(m.recentRows[i])
+	}
+
 	return m, nil
 }
 
@@ -1381,15 +2809,17 @@ func (m *Model) expandCurrent() {
 
 	switch item.Type {
 	case ItemTypeSession:
-		// Collapse all other sessions first
-		for i := range m.sessions {
-			m.sessions[i].Expanded = false
+		// Collapse all other sessions first (single-expand mode)
+		if m.config.SingleExpand {
+			for i := range m.sessions {
+				m.sessions[i].Expanded = false
+			}
 		}
 
 		session := &m.sessions[item.SessionIndex]
 		if len(session.Windows) == 0 {
 			// Load windows lazily
-			windows, err := tmux.ListWindows(session.Name)
+			windows, err := m.listWindows(session.Name)
 			if err != nil {
 				m.setError("Error loading windows: %v", err)
 				return
@@ -1403,14 +2833,16 @@ func (m *Model) expandCurrent() {
 		session := &m.sessions[item.SessionIndex]
 		window := &session.Windows[item.WindowIndex]
 
-		// Collapse other windows in this session first
-		for i := range session.Windows {
-			session.Windows[i].Expanded = false
+		// Collapse other windows in this session first (single-expand mode)
+		if m.config.SingleExpand {
+			for i := range session.Windows {
+				session.Windows[i].Expanded = false
+			}
 		}
 
 		if len(window.Panes) == 0 {
 			// Load panes lazily
-			panes, err := tmux.ListPanes(session.Name, window.Index)
+			panes, err := m.tmuxClient().ListPanes(session.Name, window.Index)
 			if err != nil {
 				m.setError("Error loading panes: %v", err)
 				return
@@ -1425,22 +2857,218 @@ func (m *Model) expandCurrent() {
 	}
 }
 
-func (m *Model) collapseCurrent() {
-	if !m.isCursorValid() {
-		return
+// listWindows loads a session's windows in the configured window_sort order
+func (m *Model) listWindows(sessionName string) ([]tmux.Window, error) {
+	return listWindowsSorted(m.tmuxClient(), sessionName, m.config.WindowSort)
+}
+
+// listWindowsSorted loads a session's windows in the given window_sort order
+func listWindowsSorted(client tmux.Client, sessionName, order string) ([]tmux.Window, error) {
+	windows, err := client.ListWindows(sessionName)
+	if err != nil {
+		return nil, err
+	}
+	if order == config.WindowSortActivity {
+		tmux.SortWindowsByActivity(windows)
 	}
+	return windows, nil
+}
 
-	item := m.items[m.cursor]
+// loadWindowsWorkers bounds the concurrent list-windows calls of a background load
+const loadWindowsWorkers = 4
 
-	switch item.Type {
-	case ItemTypeSession:
-		// Collapse the session
-		m.sessions[item.SessionIndex].Expanded = false
-		m.rebuildItems()
+// windowsPurpose says what a background window load is for
+type windowsPurpose int
 
-	case ItemTypeWindow:
-		// Collapse parent session, move cursor to session
-		sessionIdx := item.SessionIndex
+const (
+	windowsForExpandAll windowsPurpose = iota // S-→
+	windowsForStart                           // expand_on_start
+	windowsForFilter                          // Filter matching window names
+	windowsForReload                          // Refresh of expanded sessions after a load
+)
+
+// windowsLoadedMsg carries windows loaded in the background by session name
+type windowsLoadedMsg struct {
+	windows map[string][]tmux.Window
+	purpose windowsPurpose
+}
+
+// missingWindows returns the sessions whose windows aren't loaded yet
+func (m *Model) missingWindows() []string {
+	var missing []string
+	for _, s := range m.sessions {
+		if len(s.Windows) == 0 {
+			missing = append(missing, s.Name)
+		}
+	}
+	return missing
+}
+
+// loadWindowsCmd lists the windows of the named sessions in the background,
+// by a few workers instead of one burst; sessions that fail are left out
+func (m *Model) loadWindowsCmd(names []string, purpose windowsPurpose) tea.Cmd {
+	if len(names) == 0 {
+		return nil
+	}
+	order, client := m.config.WindowSort, m.tmuxClient()
+	return func() tea.Msg {
+		queue := make(chan string)
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		msg := windowsLoadedMsg{windows: make(map[string][]tmux.Window, len(names)), purpose: purpose}
+		for range min(loadWindowsWorkers, len(names)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for name := range queue {
+					windows, err := listWindowsSorted(client, name, order)
+					if err != nil {
+						continue
+					}
+					mu.Lock()
+					msg.windows[name] = windows
+					mu.Unlock()
+				}
+			}()
+		}
+		for _, name := range names {
+			queue <- name
+		}
+		close(queue)
+		wg.Wait()
+		return msg
+	}
+}
+
+// expandAllCmd expands every session (S-→), loading missing windows first
+func (m *Model) expandAllCmd() tea.Cmd {
+	missing := m.missingWindows()
+	if len(missing) == 0 {
+		return m.applyExpandAll(nil)
+	}
+	return m.loadWindowsCmd(missing, windowsForExpandAll)
+}
+
+// applyLoadedWindows stores windows loaded in the background for msg.purpose,
+// keeping the cursor on the same item
+func (m *Model) applyLoadedWindows(msg windowsLoadedMsg) tea.Cmd {
+	if msg.purpose == windowsForExpandAll {
+		return m.applyExpandAll(msg.windows)
+	}
+	if msg.purpose == windowsForFilter {
+		m.filterWindowsLoading = false
+	}
+
+	var selectedTarget string
+	if m.isCursorValid() {
+		selectedTarget = m.getTargetName(m.items[m.cursor])
+	}
+	for i := range m.sessions {
+		session := &m.sessions[i]
+		windows, ok := msg.windows[session.Name]
+		if !ok {
+			continue
+		}
+		switch msg.purpose {
+		case windowsForStart:
+			if len(session.Windows) == 0 {
+				session.Windows = windows
+			}
+			session.Expanded = true
+		case windowsForFilter:
+			if len(session.Windows) == 0 {
+				session.Windows = windows
+			}
+		case windowsForReload:
+			if session.Expanded {
+				session.Windows = keepWindowState(session.Windows, windows)
+			}
+		}
+	}
+	m.rebuildItems()
+	m.restoreCursor(selectedTarget)
+	return nil
+}
+
+// applyExpandAll stores loaded windows and expands every session that has some,
+// keeping the cursor on the same item
+func (m *Model) applyExpandAll(loaded map[string][]tmux.Window) tea.Cmd {
+	var selectedTarget string
+	if m.isCursorValid() {
+		selectedTarget = m.getTargetName(m.items[m.cursor])
+	}
+
+	expanded := 0
+	for i := range m.sessions {
+		session := &m.sessions[i]
+		if windows, ok := loaded[session.Name]; ok && len(session.Windows) == 0 {
+			session.Windows = windows
+		}
+		if len(session.Windows) > 0 {
+			session.Expanded = true
+			expanded++
+		}
+	}
+	m.rebuildItems()
+	m.restoreCursor(selectedTarget)
+	m.setMessage("Expanded %s", pluralize(expanded, "session"))
+	return clearMessageAfter(2 * time.Second)
+}
+
+// collapseAll collapses every session (S-←); a cursor on a window or pane
+// moves to its session so it stays on a visible item
+func (m *Model) collapseAll() tea.Cmd {
+	var selectedTarget string
+	if m.isCursorValid() {
+		item := m.items[m.cursor]
+		selectedTarget = m.getTargetName(item)
+		if item.Type != ItemTypeRecent {
+			selectedTarget = m.sessions[item.SessionIndex].Name
+		}
+	}
+
+	for i := range m.sessions {
+		m.sessions[i].Expanded = false
+	}
+	m.rebuildItems()
+	m.restoreCursor(selectedTarget)
+	m.setMessage("Collapsed all sessions")
+	return clearMessageAfter(2 * time.Second)
+}
+
+// expandOnStartCmd expands every session for expand_on_start: those with loaded
+// windows right away, the rest once their windows arrive
+func (m *Model) expandOnStartCmd() tea.Cmd {
+	for i := range m.sessions {
+		if len(m.sessions[i].Windows) > 0 {
+			m.sessions[i].Expanded = true
+		}
+	}
+	return m.loadWindowsCmd(m.missingWindows(), windowsForStart)
+}
+
+func (m *Model) collapseCurrent() {
+	if !m.isCursorValid() {
+		return
+	}
+
+	item := m.items[m.cursor]
+
+	switch item.Type {
+	case ItemTypeSession:
+		// Collapse the session
+		m.sessions[item.SessionIndex].Expanded = false
+		m.rebuildItems()
+
+	case ItemTypeWindow:
+		// An expanded window folds its panes first, like a tree view
+		if window := &m.sessions[item.SessionIndex].Windows[item.WindowIndex]; window.Expanded {
+			window.Expanded = false
+			m.rebuildItems()
+			return
+		}
+		// Collapse parent session, move cursor to session
+		sessionIdx := item.SessionIndex
 		m.sessions[sessionIdx].Expanded = false
 		// Move cursor to the session
 		for i, it := range m.items {
@@ -1472,8 +3100,19 @@ func (m *Model) selectCurrent() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	target := m.getTargetName(m.items[m.cursor])
-	if err := tmux.SwitchClient(target); err != nil {
+	item := m.items[m.cursor]
+	if item.Type == ItemTypeRecent {
+		return m.createSessionFromDir(m.recentRows[item.RecentIndex])
+	}
+	target := m.getTargetName(item)
+	if item.Type == ItemTypeSession {
+		// With include_current the current session is a row; picking it just closes
+		if target == m.currentSession && !m.printSelection {
+			return m, tea.Quit
+		}
+		target = m.sessionTarget(target)
+	}
+	if err := m.switchClient(target); err != nil {
 		m.setError("Error: %v", err)
 		return m, nil
 	}
@@ -1481,34 +3120,215 @@ func (m *Model) selectCurrent() (tea.Model, tea.Cmd) {
 	return m, tea.Quit
 }
 
+// sessionTarget returns the switch target for a session row: the session itself,
+// or its last active window with switch_to_last_window
+func (m *Model) sessionTarget(name string) string {
+	if !m.config.SwitchToLastWindow {
+		return name
+	}
+	index, err := m.tmuxClient().LastActiveWindow(name)
+	if err != nil {
+		return name
+	}
+	return fmt.Sprintf("%s:%d", name, index)
+}
+
+// sessionPath returns a session's working directory from sessionPaths
+// Paths are resolved in the background after every load (sessionPathMsg), so this
+// misses until the session's path has arrived instead of blocking on tmux
+func (m *Model) sessionPath(name string) (string, bool) {
+	path, ok := m.sessionPaths[name]
+	return path, ok
+}
+
+// selectedSessionPath returns the directory of the selected session (the parent
+// session for windows and panes), setting an error if it can't be resolved
+func (m *Model) selectedSessionPath() (string, bool) {
+	if !m.isCursorValid() || m.items[m.cursor].Type == ItemTypeRecent {
+		return "", false
+	}
+
+	session := m.sessions[m.items[m.cursor].SessionIndex]
+	path, ok := m.sessionPath(session.Name)
+	if !ok {
+		m.setError("Could not get session path")
+		return "", false
+	}
+	return path, true
+}
+
+// launchPopupInDir schedules command (a shell command line) in a tmux popup (sized
+// by lazygit_popup) to open after helm closes, starting in dir unless it is "",
+// then reopens helm with the same dimensions. The command's binary must be installed
+func (m *Model) launchPopupInDir(command, dir string) (tea.Model, tea.Cmd) {
+	if fields := strings.Fields(command); len(fields) == 0 {
+		return m, nil
+	} else if !m.commandInstalled(fields[0]) {
+		m.setError("%s is not installed", fields[0])
+		return m, clearMessageAfter(3 * time.Second)
+	}
+
+	_ = m.tmuxClient().RunShell(m.popupScript(command, dir))
+	return m, tea.Quit
+}
+
+// popupScript is the run-shell script behind launchPopupInDir; command and dir
+// are quoted, so quotes or $ in them reach the popup as they are
+func (m *Model) popupScript(command, dir string) string {
+	popup := fmt.Sprintf("tmux display-popup -w%s -h%s", m.config.LazygitPopup.Width, m.config.LazygitPopup.Height)
+	if dir != "" {
+		popup += " -d " + shell.Quote(dir)
+	}
+	return fmt.Sprintf("sleep 0.1 && %s -E %s; tmux display-popup -w%d -h%d -B -E %s",
+		popup, shell.Quote(command), m.width, m.height, shell.Quote(config.Command()))
+}
+
+// commandInstalled reports whether bin is on the tmux server's PATH, which popup
+// shells get; helm's own PATH is the fallback when the server's can't be read
+func (m *Model) commandInstalled(bin string) bool {
+	path, err := m.tmuxClient().GlobalEnv("PATH")
+	if err != nil || strings.Contains(bin, "/") {
+		_, err := exec.LookPath(bin)
+		return err == nil
+	}
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(dir, bin)); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Model) openLazygit() (tea.Model, tea.Cmd) {
-	if !m.isCursorValid() {
+	if m.denyPopups() {
+		return m, clearMessageAfter(2 * time.Second)
+	}
+	path, ok := m.selectedSessionPath()
+	if !ok {
 		return m, nil
 	}
+	return m.launchPopupInDir("lazygit", path)
+}
 
-	item := m.items[m.cursor]
-	if item.Type != ItemTypeSession {
-		// For windows/panes, use the parent session
-		item = Item{Type: ItemTypeSession, SessionIndex: item.SessionIndex}
+// openFileManager opens file_manager_cmd (yazi by default) in the selected session's directory
+func (m *Model) openFileManager() (tea.Model, tea.Cmd) {
+	if m.denyPopups() {
+		return m, clearMessageAfter(2 * time.Second)
 	}
+	path, ok := m.selectedSessionPath()
+	if !ok {
+		return m, nil
+	}
+	return m.launchPopupInDir(m.config.FileManagerCmd, path)
+}
 
-	session := m.sessions[item.SessionIndex]
-	path, err := git.GetSessionPath(session.Name)
-	if err != nil || path == "" {
+// editConfig opens config.yml in $EDITOR in a popup (via `helm config`, which creates it
+// if missing), then reopens helm so the edited config is loaded
+func (m *Model) editConfig() (tea.Model, tea.Cmd) {
+	if m.denyPopups() {
+		return m, clearMessageAfter(2 * time.Second)
+	}
+	editor, err := config.Editor()
+	if err != nil {
+		m.setError("Set $EDITOR to edit %s", tildePath(config.Path()))
+		return m, clearMessageAfter(3 * time.Second)
+	}
+
+	// The popup's shell starts from the tmux server environment, so pass the editor along
+	return m.launchPopupInDir(fmt.Sprintf("env EDITOR=%s %s config", shell.Quote(editor), config.Command()), "")
+}
+
+// duplicateSession creates another session in the selected session's directory
+// named "<name>-2" (or the next free suffix), applies the layout and switches to it
+func (m *Model) duplicateSession() (tea.Model, tea.Cmd) {
+	if !m.isCursorValid() || m.items[m.cursor].Type == ItemTypeRecent {
+		return m, nil
+	}
+
+	session := m.sessions[m.items[m.cursor].SessionIndex]
+	path, ok := m.sessionPath(session.Name)
+	if !ok {
 		m.setError("Could not get session path")
 		return m, nil
 	}
 
-	// Schedule lazygit popup to open after helm closes, then reopen helm with same dimensions
-	cmd := fmt.Sprintf("sleep 0.1 && tmux display-popup -w%s -h%s -d '%s' -E lazygit; tmux display-popup -w%d -h%d -B -E helm",
-		m.config.LazygitPopup.Width, m.config.LazygitPopup.Height, path, m.width, m.height)
-	_ = exec.Command("tmux", "run-shell", "-b", cmd).Start()
+	name := uniqueSessionName(session.Name, m.tmuxClient().SessionExists)
+	if err := m.tmuxClient().CreateSession(name, path, m.config.SessionShell); err != nil {
+		m.setError("Error: %v", err)
+		return m, nil
+	}
+	m.applyLayout(name, path)
 
+	if err := m.switchClient(name); err != nil {
+		m.setError("Created but failed to switch: %v", err)
+		return m, m.loadSessions
+	}
 	return m, tea.Quit
 }
 
+// uniqueSessionName appends -2, -3, ... to base until exists reports a free name
+func uniqueSessionName(base string, exists func(string) bool) string {
+	for n := 2; ; n++ {
+		name := fmt.Sprintf("%s-%d", base, n)
+		if !exists(name) {
+			return name
+		}
+	}
+}
+
+// openGitHubList shows `gh <kind> list` for the selected session's repo in a popup
+// kind is "pr" or "issue"
+func (m *Model) openGitHubList(kind string) (tea.Model, tea.Cmd) {
+	if m.denyPopups() {
+		return m, clearMessageAfter(2 * time.Second)
+	}
+	path, ok := m.selectedSessionPath()
+	if !ok {
+		return m, nil
+	}
+
+	url, err := git.RemoteURL(path)
+	if err != nil {
+		m.setError("Not a git repository with an origin remote")
+		return m, nil
+	}
+	repo := github.RepoFromURL(url)
+	if repo == "" {
+		m.setError("Not a GitHub repository: %s", url)
+		return m, nil
+	}
+
+	// Keep the popup open after gh prints, then reopen helm with same dimensions
+	list := fmt.Sprintf("gh %s list -R %s; printf '\\nPress enter to close'; read _", kind, shell.Quote(repo))
+	return m.launchPopupInDir(list, path)
+}
+
 func (m *Model) confirmKill() (tea.Model, tea.Cmd) {
-	if !m.isCursorValid() {
+	if m.denyReadOnly() {
+		return m, clearMessageAfter(readOnlyMessageDuration)
+	}
+
+	// Marked items take precedence over the cursor
+	if len(m.selected) > 0 {
+		kills := m.markedKills()
+		if len(kills) == 0 {
+			m.selected = nil
+			return m, nil
+		}
+		noun := "item"
+		if allSessions(kills) {
+			noun = "session"
+		}
+		m.killTarget = pluralize(len(kills), noun)
+		m.message = fmt.Sprintf("Kill %s?", m.killTarget)
+		m.mode = ModeConfirmKill
+		return m, nil
+	}
+
+	if !m.isCursorValid() || m.items[m.cursor].Type == ItemTypeRecent {
 		return m, nil
 	}
 
@@ -1518,6 +3338,9 @@ func (m *Model) confirmKill() (tea.Model, tea.Cmd) {
 	switch item.Type {
 	case ItemTypeSession:
 		m.message = fmt.Sprintf("Kill \"%s\"?", m.killTarget)
+		if attached := m.sessions[item.SessionIndex].Attached; attached > 0 {
+			m.message = fmt.Sprintf("Session is attached in %s — kill anyway?", pluralize(attached, "client"))
+		}
 	case ItemTypeWindow:
 		m.message = fmt.Sprintf("Kill window \"%s\"?", m.killTarget)
 	case ItemTypePane:
@@ -1528,6 +3351,228 @@ func (m *Model) confirmKill() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// confirmKillRemoveDir turns a single session's kill prompt into "kill and delete
+// its project directory", which needs one more C-x (two with uncommitted changes).
+// Only projects under project_dirs qualify, like the picker's remove, so $HOME or
+// a system path can't be deleted
+func (m *Model) confirmKillRemoveDir() {
+	if len(m.selected) > 0 || !m.isCursorValid() || m.items[m.cursor].Type != ItemTypeSession {
+		return
+	}
+
+	session := m.sessions[m.items[m.cursor].SessionIndex]
+	dir, ok := m.sessionProjectDir(session.Name)
+	if !ok {
+		return
+	}
+
+	m.killRemovePath = dir
+	m.killRemoveArmed = false
+	m.killRemoveDirty = 0
+	if status := git.GetStatus(dir); status.IsRepo && status.Dirty > 0 {
+		m.killRemoveDirty = status.Dirty
+		m.setError("Kill \"%s\" and DELETE %s with %d uncommitted changes? This cannot be undone",
+			session.Name, tildePath(dir), status.Dirty)
+		return
+	}
+	m.setError("Kill \"%s\" and DELETE %s from disk? This cannot be undone", session.Name, tildePath(dir))
+}
+
+// sessionProjectDir resolves the project directory a session works in, asking
+// tmux for its current path instead of trusting the sessionPaths cache
+// Reports an error and false if it can't be resolved or isn't removable
+func (m *Model) sessionProjectDir(name string) (string, bool) {
+	path, err := m.tmuxClient().DisplayPath(name)
+	if err != nil || path == "" {
+		m.setError("Could not get session path")
+		return "", false
+	}
+	if m.sessionPaths != nil {
+		m.sessionPaths[name] = path
+	}
+	dir, ok := m.removableDir(path)
+	if !ok {
+		m.setError("Only projects under project_dirs can be removed: %s", tildePath(path))
+		return "", false
+	}
+	return dir, true
+}
+
+// removableDir returns the project directory path belongs to: the entry
+// project_depth levels below its project_dirs root (what the picker lists), or
+// for a path above that depth its git work tree if that lies below the root
+// Reports false for paths outside project_dirs and for organisation directories
+func (m *Model) removableDir(path string) (string, bool) {
+	path = filepath.Clean(path)
+	root := projectRoot(path, m.config.ProjectDirs)
+	if root == "" {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return "", false
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+	if depth := max(m.config.ProjectDepth, 1); len(parts) >= depth {
+		return filepath.Join(append([]string{root}, parts[:depth]...)...), true
+	}
+
+	if top, err := git.TopLevel(path); err == nil && projectRoot(filepath.Clean(top), m.config.ProjectDirs) == root {
+		return filepath.Clean(top), true
+	}
+	return "", false
+}
+
+// killAndRemoveDir kills killTarget and deletes killRemovePath, after checking
+// the session still works in that project
+func (m *Model) killAndRemoveDir() (tea.Model, tea.Cmd) {
+	name, path := m.killTarget, m.killRemovePath
+	m.mode = ModeNormal
+	m.killTarget = ""
+	m.resetKillRemove()
+
+	if dir, ok := m.sessionProjectDir(name); !ok {
+		return m, m.loadSessions
+	} else if dir != path {
+		m.setError("\"%s\" moved to %s; nothing was killed or removed", name, tildePath(dir))
+		return m, m.loadSessions
+	}
+
+	if err := m.tmuxClient().KillSession(name); err != nil {
+		m.setError("Error: %v", err)
+		return m, m.loadSessions
+	}
+	if err := os.RemoveAll(path); err != nil {
+		m.setError("Killed \"%s\" but failed to remove: %v", name, err)
+		return m, m.loadSessions
+	}
+
+	m.setMessage("Killed \"%s\" and removed %s", name, tildePath(path))
+	return m, tea.Batch(m.loadSessions, clearMessageAfter(5*time.Second))
+}
+
+// yankSwitchCommand copies the shell command that switches to the selected item
+func (m *Model) yankSwitchCommand() tea.Cmd {
+	if !m.isCursorValid() || m.items[m.cursor].Type == ItemTypeRecent {
+		return nil
+	}
+	command := switchCommand(m.config.YankCommand, m.getTargetName(m.items[m.cursor]))
+	if err := clipboard.Copy(command); err != nil {
+		m.setError("%v", err)
+		return nil
+	}
+	m.setMessage("Copied: %s", command)
+	return clearMessageAfter(3 * time.Second)
+}
+
+// switchCommand returns the shell command that switches a client to target
+// form is yank_command: "helm" for `helm switch`, anything else for plain tmux
+func switchCommand(form, target string) string {
+	if form == config.YankCommandHelm {
+		return "helm switch " + shell.Quote(target)
+	}
+	return "tmux switch-client -t " + shell.Quote(target)
+}
+
+// toggleMark marks or unmarks the item under the cursor for a batch kill
+func (m *Model) toggleMark() {
+	if !m.isCursorValid() || m.items[m.cursor].Type == ItemTypeRecent {
+		return
+	}
+	target := m.getTargetName(m.items[m.cursor])
+	if m.selected[target] {
+		delete(m.selected, target)
+		return
+	}
+	if m.selected == nil {
+		m.selected = make(map[string]bool)
+	}
+	m.selected[target] = true
+}
+
+// isMarked reports whether the item is marked for a batch kill
+func (m *Model) isMarked(item Item) bool {
+	return len(m.selected) > 0 && item.Type != ItemTypeRecent && m.selected[m.getTargetName(item)]
+}
+
+// markedKill is one kill of a batch, resolved from the marked target names
+type markedKill struct {
+	target  string // Marked target name
+	kind    ItemType
+	session string
+	window  int
+	pane    int
+}
+
+// markedKills resolves the marked items in kill order. A marked session covers its
+// marked windows and panes; windows and panes go highest index first so earlier
+// kills don't renumber later targets (renumber-windows). Stale marks are dropped
+func (m *Model) markedKills() []markedKill {
+	var kills []markedKill
+	for _, session := range m.sessions {
+		if m.selected[session.Name] {
+			kills = append(kills, markedKill{target: session.Name, kind: ItemTypeSession, session: session.Name})
+			continue
+		}
+		for wi := len(session.Windows) - 1; wi >= 0; wi-- {
+			window := session.Windows[wi]
+			if target := fmt.Sprintf("%s:%d", session.Name, window.Index); m.selected[target] {
+				kills = append(kills, markedKill{target: target, kind: ItemTypeWindow, session: session.Name, window: window.Index})
+				continue
+			}
+			for pi := len(window.Panes) - 1; pi >= 0; pi-- {
+				pane := window.Panes[pi]
+				if target := fmt.Sprintf("%s:%d.%d", session.Name, window.Index, pane.Index); m.selected[target] {
+					kills = append(kills, markedKill{target: target, kind: ItemTypePane, session: session.Name, window: window.Index, pane: pane.Index})
+				}
+			}
+		}
+	}
+	return kills
+}
+
+// allSessions reports whether every kill in the batch is a session
+func allSessions(kills []markedKill) bool {
+	for _, k := range kills {
+		if k.kind != ItemTypeSession {
+			return false
+		}
+	}
+	return true
+}
+
+// killMarked kills every marked item and clears the marks
+func (m *Model) killMarked() (tea.Model, tea.Cmd) {
+	kills := m.markedKills()
+	var failed []string
+	for _, k := range kills {
+		var err error
+		switch k.kind {
+		case ItemTypeSession:
+			err = m.tmuxClient().KillSession(k.session)
+		case ItemTypeWindow:
+			err = m.tmuxClient().KillWindow(k.session, k.window)
+		case ItemTypePane:
+			err = m.tmuxClient().KillPane(k.session, k.window, k.pane)
+		}
+		if err != nil {
+			failed = append(failed, k.target)
+		}
+	}
+
+	if len(failed) > 0 {
+		m.setError("Killed %d of %d (failed: %s)", len(kills)-len(failed), len(kills), strings.Join(failed, ", "))
+	} else {
+		m.setMessage("Killed %s", m.killTarget)
+	}
+
+	m.selected = nil
+	m.mode = ModeNormal
+	m.killTarget = ""
+	return m, tea.Batch(m.loadSessions, clearMessageAfter(5*time.Second))
+}
+
 func (m *Model) killCurrent() (tea.Model, tea.Cmd) {
 	if !m.isCursorValid() {
 		return m, nil
@@ -1539,14 +3584,14 @@ func (m *Model) killCurrent() (tea.Model, tea.Cmd) {
 	switch item.Type {
 	case ItemTypeSession:
 		session := m.sessions[item.SessionIndex]
-		err = tmux.KillSession(session.Name)
+		err = m.tmuxClient().KillSession(session.Name)
 		if err == nil {
 			m.message = fmt.Sprintf("Killed \"%s\"", session.Name)
 		}
 	case ItemTypeWindow:
 		session := m.sessions[item.SessionIndex]
 		window := session.Windows[item.WindowIndex]
-		err = tmux.KillWindow(session.Name, window.Index)
+		err = m.tmuxClient().KillWindow(session.Name, window.Index)
 		if err == nil {
 			m.message = fmt.Sprintf("Killed window %d", window.Index)
 		}
@@ -1554,7 +3599,7 @@ func (m *Model) killCurrent() (tea.Model, tea.Cmd) {
 		session := m.sessions[item.SessionIndex]
 		window := session.Windows[item.WindowIndex]
 		pane := window.Panes[item.PaneIndex]
-		err = tmux.KillPane(session.Name, window.Index, pane.Index)
+		err = m.tmuxClient().KillPane(session.Name, window.Index, pane.Index)
 		if err == nil {
 			m.message = fmt.Sprintf("Killed pane %d", pane.Index)
 		}
@@ -1576,25 +3621,44 @@ func (m *Model) handleConfirmRemoveFolderMode(msg tea.KeyMsg) (tea.Model, tea.Cm
 
 	switch {
 	case key.Matches(msg, keys.Kill):
+		// Uncommitted work would be lost for good, so that takes a second C-x
+		if m.removeDirty > 0 && !m.removeArmed {
+			m.removeArmed = true
+			m.setError("Really DELETE \"%s\" with %d uncommitted changes? C-x again to confirm",
+				m.extractDisplayPath(m.removeTarget), m.removeDirty)
+			return m, nil
+		}
 		return m.removeFolder()
 	case key.Matches(msg, keys.Cancel):
 		m.mode = ModePickDirectory
 		m.message = ""
+		m.messageIsError = false
 		m.removeTarget = ""
+		m.removeDirty = 0
+		m.removeArmed = false
 	}
 
 	return m, nil
 }
 
 func (m *Model) confirmRemoveFolder() (tea.Model, tea.Cmd) {
+	if m.denyReadOnly() {
+		return m, clearMessageAfter(readOnlyMessageDuration)
+	}
 	selected, ok := m.projectList.SelectedItem()
 	if !ok {
 		return m, nil
 	}
 
 	m.removeTarget = selected
+	m.removeArmed = false
+	m.removeDirty = 0
 	displayPath := m.extractDisplayPath(m.removeTarget)
 	m.message = fmt.Sprintf("Remove \"%s\" from disk?", displayPath)
+	if status := git.GetStatus(selected); status.IsRepo && status.Dirty > 0 {
+		m.removeDirty = status.Dirty
+		m.setError("Remove \"%s\" from disk? It has uncommitted changes!", displayPath)
+	}
 	m.mode = ModeConfirmRemoveFolder
 	return m, nil
 }
@@ -1608,20 +3672,25 @@ func (m *Model) removeFolder() (tea.Model, tea.Cmd) {
 	sessionName := m.extractSessionName(m.removeTarget)
 
 	// Kill associated session if it exists
-	if tmux.SessionExists(sessionName) {
-		_ = tmux.KillSession(sessionName)
+	if m.tmuxClient().SessionExists(sessionName) {
+		_ = m.tmuxClient().KillSession(sessionName)
 	}
 
 	if err := os.RemoveAll(m.removeTarget); err != nil {
 		m.setError("Failed to remove: %v", err)
 		m.mode = ModePickDirectory
 		m.removeTarget = ""
+		m.removeDirty = 0
+		m.removeArmed = false
 		return m, nil
 	}
 
 	m.message = fmt.Sprintf("Removed \"%s\"", displayPath)
+	m.messageIsError = false
 	m.mode = ModePickDirectory
 	m.removeTarget = ""
+	m.removeDirty = 0
+	m.removeArmed = false
 
 	// Rescan directories and re-apply current filter
 	m.projectList.SetItems(m.scanProjectDirectories())
@@ -1629,22 +3698,51 @@ func (m *Model) removeFolder() (tea.Model, tea.Cmd) {
 	return m, clearMessageAfter(5 * time.Second)
 }
 
-func (m *Model) createSession(name string) (tea.Model, tea.Cmd) {
+// splitCreateInput splits the create input "name -- command" into its parts
+// Without a " -- " separator the whole input is the name
+func splitCreateInput(input string) (name, command string) {
+	name, command, _ = strings.Cut(input, " -- ")
+	name = strings.TrimSpace(name)
+	if runes := []rune(name); len(runes) > sessionNameLimit {
+		name = string(runes[:sessionNameLimit])
+	}
+	return name, strings.TrimSpace(command)
+}
+
+// createSession creates a session in default_session_dir and switches to it
+// With a command, it is typed into the first window instead of applying the layout
+// An existing session with that name is switched to instead
+func (m *Model) createSession(name, command string) (tea.Model, tea.Cmd) {
 	// Sanitize session name (spaces, dots, colons break tmux target syntax)
 	name = sanitizeSessionName(name)
+	if m.tmuxClient().SessionExists(name) {
+		if err := m.switchClient(name); err != nil {
+			m.setError("Failed to switch: %v", err)
+			return m, m.loadSessions
+		}
+		return m, tea.Quit
+	}
+
 	workingDir := m.config.DefaultSessionDir
-	if err := tmux.CreateSession(name, workingDir); err != nil {
+	if err := m.tmuxClient().CreateSession(name, workingDir, m.config.SessionShell); err != nil {
 		m.setError("Error: %v", err)
 		m.mode = ModeNormal
 		m.input.Blur()
 		return m, nil
 	}
 
-	// Apply layout if configured
-	m.applyLayout(name, workingDir)
+	if command != "" {
+		if err := m.tmuxClient().RunInSession(name, command); err != nil {
+			m.setError("Created but failed to run %q: %v", command, err)
+			return m, m.loadSessions
+		}
+	} else {
+		// Apply layout if configured
+		m.applyLayout(name, workingDir)
+	}
 
 	// Switch to the new session
-	if err := tmux.SwitchClient(name); err != nil {
+	if err := m.switchClient(name); err != nil {
 		m.setError("Created but failed to switch: %v", err)
 		return m, m.loadSessions
 	}
@@ -1662,13 +3760,45 @@ func (m *Model) applyLayout(sessionName, workingDir string) {
 		return
 	}
 
-	// Run layout script synchronously before switching to the session
-	cmd := exec.Command(scriptPath, sessionName, workingDir)
+	// Run layout script synchronously before switching to the session,
+	// killing it if it hangs so the switch still happens
+	timeout := m.config.LayoutTimeoutDuration()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, scriptPath, sessionName, workingDir)
 	cmd.Env = append(os.Environ(),
 		"TMUX_SESSION="+sessionName,
 		"TMUX_WORKING_DIR="+workingDir,
 	)
-	_ = cmd.Run()
+	// Children of the script may keep the output pipe open after it is killed
+	cmd.WaitDelay = layoutWaitDelay
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		return
+	}
+
+	// The switch still happens and helm usually quits right after, so besides
+	// the model message also show the error in tmux's status line
+	if ctx.Err() == context.DeadlineExceeded {
+		m.setError("Layout '%s' timed out after %s", m.config.Layout, timeout)
+	} else {
+		m.setError("Layout '%s' failed: %s", m.config.Layout, firstLine(out, err))
+	}
+	_ = m.tmuxClient().DisplayMessage("helm: " + m.message)
+}
+
+// layoutWaitDelay bounds how long a killed layout script's output is waited for
+const layoutWaitDelay = time.Second
+
+// firstLine returns the first non-empty line of a command's output,
+// falling back to the error (e.g. "exit status 1") when there is none
+func firstLine(out []byte, err error) string {
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return err.Error()
 }
 
 func (m *Model) loadClaudeStatuses() {
@@ -1676,124 +3806,590 @@ func (m *Model) loadClaudeStatuses() {
 	if !m.config.ClaudeStatusEnabled {
 		return
 	}
+	defer log.Since("load claude statuses", time.Now())
 	for _, s := range m.sessions {
-		status := claude.GetStatus(s.Name, m.config.CacheDir)
+		status := claude.GetStatus(s.Name, m.config.CacheDir, claude.Options{
+			Format:         claude.Format(m.config.ClaudeStatusFormat),
+			StaleThreshold: m.config.ClaudeStaleDuration(),
+		})
 		if status.State != "" {
 			m.claudeStatuses[s.Name] = status
 		}
 	}
 }
 
-// fetchGitStatusesCmd returns commands that fetch git statuses in parallel
+// resolveSessionPathsCmd resolves each session's working directory (for path filtering)
+// and, with project icons enabled, detects its project type
+// One command per session, so each git status starts as soon as its path arrives
+// The detector caches results so paths are only stat'ed once
+func (m *Model) resolveSessionPathsCmd() tea.Cmd {
+	if len(m.sessions) == 0 {
+		return nil
+	}
+	// Drop sessions that are gone; the rest are refreshed as their paths arrive
+	live := make(map[string]bool, len(m.sessions))
+	for _, s := range m.sessions {
+		live[s.Name] = true
+	}
+	maps.DeleteFunc(m.sessionPaths, func(name, _ string) bool { return !live[name] })
+	maps.DeleteFunc(m.projectIcons, func(name, _ string) bool { return !live[name] })
+
+	detector, client := m.projectDetector, m.tmuxClient()
+	cmds := make([]tea.Cmd, 0, len(m.sessions)+1)
+	cmds = append(cmds, m.trackGitStatuses())
+	for _, s := range m.sessions {
+		name := s.Name // capture for closure
+		cmds = append(cmds, func() tea.Msg {
+			msg := sessionPathMsg{name: name}
+			path, err := client.DisplayPath(name)
+			if err != nil || path == "" {
+				return msg
+			}
+			msg.path = path
+			if detector == nil {
+				return msg
+			}
+			if marker, ok := detector.Detect(path); ok {
+				msg.icon = marker.Icon
+			}
+			return msg
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// envVersionsCmd reads the pinned runtime versions of visible session rows that
+// don't have them yet. Rows are marked as loading first so each is read once
+func (m *Model) envVersionsCmd() tea.Cmd {
+	if m.envReader == nil || m.mode != ModeNormal || m.compact {
+		return nil
+	}
+	if m.envVersions == nil {
+		m.envVersions = make(map[string]string)
+	}
+
+	end := m.sessionVisibleEnd(m.scrollOffset)
+	paths := make(map[string]string)
+	for i := m.scrollOffset; i < end; i++ {
+		if m.items[i].Type != ItemTypeSession {
+			continue
+		}
+		name := m.sessions[m.items[i].SessionIndex].Name
+		path, ok := m.sessionPaths[name]
+		if _, loaded := m.envVersions[name]; loaded || !ok {
+			continue
+		}
+		m.envVersions[name] = ""
+		paths[name] = path
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	reader := m.envReader
+	return func() tea.Msg {
+		msg := envVersionsMsg{badges: make(map[string]string, len(paths))}
+		for name, path := range paths {
+			msg.badges[name] = project.EnvBadge(reader.Versions(path))
+		}
+		return msg
+	}
+}
+
+// projectIconWidth returns the width of the project icon column (0 when disabled)
+func (m Model) projectIconWidth() int {
+	if m.projectDetector == nil || m.compact {
+		return 0
+	}
+	width := 0
+	for _, marker := range m.projectDetector.Markers() {
+		width = max(width, lipgloss.Width(marker.Icon))
+	}
+	return width
+}
+
+// watchGlyphWidth returns the width of the watch glyph column (0 when no
+// watch_commands are configured or in compact mode)
+func (m Model) watchGlyphWidth() int {
+	if len(m.config.WatchCommands) == 0 || m.compact {
+		return 0
+	}
+	return lipgloss.Width(m.config.WatchGlyph)
+}
+
+// paneMarkWidth returns the width of the marked pane column (0 when no listed
+// session holds tmux's marked pane, or in compact mode)
+func (m Model) paneMarkWidth() int {
+	if m.compact {
+		return 0
+	}
+	for _, s := range m.sessions {
+		if s.PaneMarked {
+			return lipgloss.Width(ui.PaneMarkGlyph)
+		}
+	}
+	return 0
+}
+
+// starWidth returns the width of the favorite star column (0 when no listed
+// session is starred, or in compact mode)
+func (m Model) starWidth() int {
+	if m.compact {
+		return 0
+	}
+	for _, s := range m.sessions {
+		if m.starred[s.Name] {
+			return lipgloss.Width(ui.StarGlyph)
+		}
+	}
+	return 0
+}
+
+// deadWidth returns the width of the dead pane column: the widest "✗N" label,
+// or 0 (hidden) when no listed session has dead panes, or in compact mode
+func (m Model) deadWidth() int {
+	if m.compact {
+		return 0
+	}
+	width := 0
+	for _, s := range m.sessions {
+		width = max(width, lipgloss.Width(ui.DeadPanesLabel(s.DeadPanes)))
+	}
+	return width
+}
+
+// windowCountWidth returns the width of the window count column: the digits of
+// the largest count, or 0 (hidden) when no counts are known
+func (m Model) windowCountWidth() int {
+	largest := 0
+	for _, s := range m.sessions {
+		largest = max(largest, s.WindowCount)
+	}
+	if largest == 0 {
+		return 0
+	}
+	return len(strconv.Itoa(largest))
+}
+
+// cleanupClaudeStatusCmd removes status files of sessions that no longer exist
+// Runs in the background so a large cache dir never delays the UI
+func (m *Model) cleanupClaudeStatusCmd() tea.Cmd {
+	if !m.config.ClaudeStatusEnabled {
+		return nil
+	}
+	// The current session is excluded from m.sessions but still alive, and so
+	// are the grouped sessions folded into their group's row
+	active := make([]string, 0, len(m.sessions)+1)
+	if m.currentSession != "" {
+		active = append(active, m.currentSession)
+	}
+	for _, s := range m.sessions {
+		active = append(active, s.Name)
+		active = append(active, s.GroupMembers...)
+	}
+	cacheDir := m.config.CacheDir
+	return func() tea.Msg {
+		claude.CleanupStale(cacheDir, active)
+		return nil
+	}
+}
+
+// gitStatusEnabled reports whether the git column is on and git is installed
+// Without git the column stays hidden instead of failing once per session
+func (m Model) gitStatusEnabled() bool {
+	return m.config.GitStatusEnabled && git.Available()
+}
+
+// fetchGitStatusesCmd returns commands that fetch git statuses in parallel from the
+// already resolved sessionPaths (e.g. after git_status_enabled is switched on)
 // Each session's status is fetched independently and updates the UI as soon as ready
 func (m *Model) fetchGitStatusesCmd() tea.Cmd {
-	if !m.config.GitStatusEnabled || len(m.sessions) == 0 {
+	if !m.gitStatusEnabled() || len(m.sessions) == 0 {
 		return nil
 	}
+	cmds := make([]tea.Cmd, 0, len(m.sessions)+1)
+	cmds = append(cmds, m.trackGitStatuses())
+	for _, s := range m.sessions {
+		cmds = append(cmds, m.gitStatusCmd(s.Name))
+	}
+	return tea.Batch(cmds...)
+}
 
-	// Track which sessions we're waiting for
+// trackGitStatuses marks every session's git status as pending and returns the
+// delayed (500ms) loading indicator
+func (m *Model) trackGitStatuses() tea.Cmd {
+	if !m.gitStatusEnabled() || len(m.sessions) == 0 {
+		return nil
+	}
 	m.gitStatusPending = make(map[string]bool)
 	m.gitStatusShowLoading = false
 	for _, s := range m.sessions {
 		m.gitStatusPending[s.Name] = true
 	}
+	return tea.Tick(500*time.Millisecond, func(time.Time) tea.Msg {
+		return gitStatusLoadingMsg{}
+	})
+}
 
-	// Create a command for each session - they run in parallel via tea.Batch
-	cmds := make([]tea.Cmd, 0, len(m.sessions)+1)
+// gitStatusCmd fetches one session's git status from its resolved path
+// A session without a path reports no status so it stops counting as pending
+func (m *Model) gitStatusCmd(sessionName string) tea.Cmd {
+	if !m.gitStatusEnabled() {
+		return nil
+	}
+	path, ok := m.sessionPaths[sessionName]
+	return func() tea.Msg {
+		defer log.Since("git status "+sessionName, time.Now())
+		if !ok {
+			return gitStatusSingleMsg{sessionName: sessionName, hasStatus: false}
+		}
+		status := git.GetStatus(path)
+		if status.IsRepo && !status.IsClean() {
+			return gitStatusSingleMsg{sessionName: sessionName, status: status, hasStatus: true}
+		}
+		return gitStatusSingleMsg{sessionName: sessionName, hasStatus: false}
+	}
+}
 
-	// Add delayed loading indicator (500ms)
-	cmds = append(cmds, tea.Tick(500*time.Millisecond, func(time.Time) tea.Msg {
-		return gitStatusLoadingMsg{}
-	}))
+// sessionDisplayName is what a session row shows: the tmux name, or with
+// name_display: path its directory (rendered like picker_display) once resolved
+func (m *Model) sessionDisplayName(s tmux.Session) string {
+	name := s.Name
+	if m.config.NameDisplay == config.NameDisplayPath {
+		if path := m.sessionPaths[s.Name]; path != "" {
+			name = displayPath(path, m.config)
+		}
+	}
+	return ui.SessionLabel(name, s.GroupSize)
+}
+
+func (m *Model) calculateColumnWidths() {
+	// Don't reset - preserve cached width to prevent layout shift
+	for _, s := range m.sessions {
+		if w := lipgloss.Width(m.sessionDisplayName(s)); w > m.maxNameWidth {
+			m.maxNameWidth = w
+		}
+	}
+	if m.currentInfo != nil && lipgloss.Width(m.currentInfo.Name) > m.maxNameWidth {
+		m.maxNameWidth = lipgloss.Width(m.currentInfo.Name)
+	}
+}
+
+// stateText returns the state line text based on current mode and context
+func (m *Model) stateText() string {
+	switch m.mode {
+	case ModeNormal:
+		total := len(m.sessions)
+		visible := len(m.items)
+		state := fmt.Sprintf("%d sessions", total)
+		if m.filter != "" {
+			state = fmt.Sprintf("Showing %d/%d sessions", visible, total)
+		} else if m.sessionsCapped() {
+			state += fmt.Sprintf(" (showing %d of %d)", m.shownSessionCount(), total)
+		}
+		if current := m.currentSessionLabel(); current != "" {
+			state += " · " + current
+		}
+		if m.readOnly {
+			state += " · monitor"
+		}
+		return state
+	case ModeBookmarks:
+		total := len(m.config.Bookmarks)
+		if total == 0 {
+			return "No bookmarks"
+		}
+		return fmt.Sprintf("%d bookmarks", total)
+	case ModePickDirectory:
+		if m.pendingSessionName != "" {
+			return fmt.Sprintf("Select location for: %s", m.pendingSessionName)
+		}
+		total := len(m.projectList.Items())
+		visible := m.projectList.Len()
+		if m.projectList.Filter() != "" {
+			return fmt.Sprintf("Showing %d/%d projects", visible, total)
+		}
+		return fmt.Sprintf("%d projects", total)
+	case ModeCloneRepo:
+		if m.cloneSuccess {
+			return "Clone successful"
+		}
+		if m.cloneCloning {
+			return fmt.Sprintf("Cloning %s...", m.cloneCloningRepo)
+		}
+		if m.cloneURLMode {
+			return "Clone from URL"
+		}
+		if m.cloneLoading {
+			return "Loading repositories..."
+		}
+		total := len(m.cloneList.Items())
+		visible := m.cloneList.Len()
+		if m.cloneList.Filter() != "" {
+			return fmt.Sprintf("Showing %d/%d repositories", visible, total)
+		}
+		return fmt.Sprintf("%d repositories", total)
+	case ModeCreate:
+		if m.createExists {
+			return "Exists: Enter switches to it"
+		}
+		return "Enter session name"
+	case ModeConfirmKill:
+		if len(m.selected) > 0 {
+			return fmt.Sprintf("Kill %s?", m.killTarget)
+		}
+		return fmt.Sprintf("Kill session: %s?", m.killTarget)
+	case ModeConfirmRemoveFolder:
+		if m.removeDirty > 0 {
+			return fmt.Sprintf("Remove folder: %s? (%d uncommitted)", filepath.Base(m.removeTarget), m.removeDirty)
+		}
+		return fmt.Sprintf("Remove folder: %s?", filepath.Base(m.removeTarget))
+	case ModeConfirmQuit:
+		return "Quit helm?"
+	case ModeConfirmClearClaude:
+		return "Clear Claude statuses?"
+	case ModeBroadcast:
+		if m.broadcastCommand != "" {
+			return "Confirm sending to all panes"
+		}
+		return "Command for all panes of " + m.broadcastTarget
+	case ModeGoTo:
+		return "Enter session number"
+	default:
+		return ""
+	}
+}
+
+// selectedPathCmd resolves the working directory of the selected window or pane
+// Session paths come from resolveSessionPathsCmd; only the selected item is fetched
+func (m *Model) selectedPathCmd() tea.Cmd {
+	if m.mode != ModeNormal || !m.isCursorValid() {
+		return nil
+	}
+	item := m.items[m.cursor]
+	if item.Type != ItemTypeWindow && item.Type != ItemTypePane {
+		return nil
+	}
+	target := m.getTargetName(item)
+	if _, ok := m.itemPaths[target]; ok {
+		return nil
+	}
+	if m.itemPaths == nil {
+		m.itemPaths = make(map[string]string)
+	}
+	m.itemPaths[target] = "" // Resolving; don't fetch again
+	client := m.tmuxClient()
+	return func() tea.Msg {
+		path, _ := client.DisplayPath(target)
+		return itemPathMsg{target: target, path: path}
+	}
+}
+
+// selectionDetail describes the selected item for the notification line:
+// its working directory and, for sessions, the window count
+func (m *Model) selectionDetail() string {
+	if !m.isCursorValid() {
+		return ""
+	}
+	item := m.items[m.cursor]
+	if item.Type == ItemTypeRecent {
+		return tildePath(m.recentRows[item.RecentIndex])
+	}
+
+	session := m.sessions[item.SessionIndex]
+	if item.Type != ItemTypeSession {
+		if path := m.itemPaths[m.getTargetName(item)]; path != "" {
+			return tildePath(path)
+		}
+		return ""
+	}
+
+	var parts []string
+	if path := m.sessionPaths[session.Name]; path != "" {
+		parts = append(parts, tildePath(path))
+	}
+	count := session.WindowCount
+	if count == 0 {
+		count = len(session.Windows)
+	}
+	if count > 0 {
+		parts = append(parts, pluralize(count, "window"))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// currentSessionLabel returns "current: <name> (<dir>)" for the state line
+// The directory is shortened to ~ notation and omitted until it has been resolved
+func (m *Model) currentSessionLabel() string {
+	if m.currentSession == "" {
+		return ""
+	}
+	if m.currentPath == "" {
+		return "current: " + m.currentSession
+	}
+	return fmt.Sprintf("current: %s (%s)", m.currentSession, tildePath(m.currentPath))
+}
+
+// sessionsCapped reports whether max_sessions or active_within hides sessions from
+// the unfiltered list
+func (m Model) sessionsCapped() bool {
+	return m.shownSessionCount() < len(m.sessions)
+}
+
+// shownSessionCount returns how many sessions the unfiltered list shows
+// Sessions are sorted by activity, so both limits keep a prefix of the list
+func (m Model) shownSessionCount() int {
+	n := len(m.sessions)
+	if m.showAllSessions {
+		return n
+	}
+	if m.config.MaxSessions > 0 {
+		n = min(n, m.config.MaxSessions)
+	}
+	if d := m.config.ActiveWithinDuration(); d > 0 {
+		cutoff := time.Now().Add(-d)
+		for i := range n {
+			// Starred sessions come first and stay listed however long they idle
+			if m.sessions[i].LastActivity.Before(cutoff) && !m.starred[m.sessions[i].Name] {
+				return i
+			}
+		}
+	}
+	return n
+}
+
+// openSessionNames returns the names of all running sessions, including the current one
+// Taken from the loaded session list rather than tmux.SessionExists per row, since
+// views re-render on every animation tick
+func (m Model) openSessionNames() map[string]bool {
+	open := make(map[string]bool, len(m.sessions)+1)
+	for _, s := range m.sessions {
+		open[s.Name] = true
+	}
+	if m.currentSession != "" {
+		open[m.currentSession] = true
+	}
+	return open
+}
+
+// tildePath shortens a path under $HOME to ~ notation
+// Only whole path components match, so /home/bobby stays as is for HOME=/home/bob
+func tildePath(path string) string {
+	home := os.Getenv("HOME")
+	if home == "" {
+		return path
+	}
+	if path == home || strings.HasPrefix(path, home+"/") {
+		return "~" + path[len(home):]
+	}
+	return path
+}
 
-	for _, s := range m.sessions {
-		sessionName := s.Name // capture for closure
-		cmds = append(cmds, func() tea.Msg {
-			path, err := git.GetSessionPath(sessionName)
-			if err != nil || path == "" {
-				return gitStatusSingleMsg{sessionName: sessionName, hasStatus: false}
-			}
-			status := git.GetStatus(path)
-			if status.IsRepo && !status.IsClean() {
-				return gitStatusSingleMsg{sessionName: sessionName, status: status, hasStatus: true}
-			}
-			return gitStatusSingleMsg{sessionName: sessionName, hasStatus: false}
-		})
+// loadWindowsForFilterCmd lazily loads window lists so the filter can match window names
+// Only sessions without loaded windows are queried, and one load at a time
+func (m *Model) loadWindowsForFilterCmd() tea.Cmd {
+	if m.filter == "" || m.filterWindowsLoading {
+		return nil
 	}
+	cmd := m.loadWindowsCmd(m.missingWindows(), windowsForFilter)
+	m.filterWindowsLoading = cmd != nil
+	return cmd
+}
 
-	return tea.Batch(cmds...)
+// preserveSessionState carries UI state (expansion, loaded windows) from the
+// previous session list over to a freshly loaded one, matched by session name
+func preserveSessionState(old, fresh []tmux.Session) []tmux.Session {
+	prev := make(map[string]tmux.Session, len(old))
+	for _, s := range old {
+		prev[s.Name] = s
+	}
+	for i := range fresh {
+		if p, ok := prev[fresh[i].Name]; ok {
+			fresh[i].Expanded = p.Expanded
+			fresh[i].Windows = p.Windows
+		}
+	}
+	return fresh
 }
 
-func (m *Model) calculateColumnWidths() {
-	// Don't reset - preserve cached width to prevent layout shift
+// reloadExpandedWindowsCmd refreshes the window list of expanded sessions in the background
+func (m *Model) reloadExpandedWindowsCmd() tea.Cmd {
+	var names []string
 	for _, s := range m.sessions {
-		if len(s.Name) > m.maxNameWidth {
-			m.maxNameWidth = len(s.Name)
+		if s.Expanded {
+			names = append(names, s.Name)
 		}
 	}
+	return m.loadWindowsCmd(names, windowsForReload)
 }
 
-// stateText returns the state line text based on current mode and context
-func (m *Model) stateText() string {
-	switch m.mode {
-	case ModeNormal:
-		total := len(m.sessions)
-		visible := len(m.items)
-		if m.filter != "" {
-			return fmt.Sprintf("Showing %d/%d sessions", visible, total)
-		}
-		return fmt.Sprintf("%d sessions", total)
-	case ModeBookmarks:
-		total := len(m.config.Bookmarks)
-		if total == 0 {
-			return "No bookmarks"
-		}
-		return fmt.Sprintf("%d bookmarks", total)
-	case ModePickDirectory:
-		if m.pendingSessionName != "" {
-			return fmt.Sprintf("Select location for: %s", m.pendingSessionName)
-		}
-		total := len(m.projectList.Items())
-		visible := m.projectList.Len()
-		if m.projectList.Filter() != "" {
-			return fmt.Sprintf("Showing %d/%d projects", visible, total)
-		}
-		return fmt.Sprintf("%d projects", total)
-	case ModeCloneRepo:
-		if m.cloneSuccess {
-			return "Clone successful"
-		}
-		if m.cloneLoading {
-			return "Loading repositories..."
-		}
-		if m.cloneCloning {
-			return fmt.Sprintf("Cloning %s...", m.cloneCloningRepo)
+// keepWindowState keeps window expansion (and loaded panes) from old for
+// windows in fresh that still exist
+func keepWindowState(old, fresh []tmux.Window) []tmux.Window {
+	for j := range fresh {
+		for _, o := range old {
+			if o.Index == fresh[j].Index && o.Expanded {
+				fresh[j].Expanded = true
+				fresh[j].Panes = o.Panes
+			}
 		}
-		total := len(m.cloneList.Items())
-		visible := m.cloneList.Len()
-		if m.cloneList.Filter() != "" {
-			return fmt.Sprintf("Showing %d/%d repositories", visible, total)
+	}
+	return fresh
+}
+
+// restoreCursor moves the cursor to the item with the given tmux target, if still present
+func (m *Model) restoreCursor(target string) {
+	if target == "" {
+		return
+	}
+	for i, item := range m.items {
+		if m.getTargetName(item) == target {
+			m.cursor = i
+			m.updateScrollOffset()
+			return
 		}
-		return fmt.Sprintf("%d repositories", total)
-	case ModeCreate:
-		return "Enter session name"
-	case ModeConfirmKill:
-		return fmt.Sprintf("Kill session: %s?", m.killTarget)
-	case ModeConfirmRemoveFolder:
-		return fmt.Sprintf("Remove folder: %s?", filepath.Base(m.removeTarget))
-	default:
-		return ""
 	}
 }
 
+// pathFilterPrefix scopes the session filter to working directories
+const pathFilterPrefix = "@"
+
+// minPathFilter is the filter length from which unscoped filters also match paths
+const minPathFilter = 3
+
 func (m *Model) rebuildItems() {
 	m.items = nil
 	filterLower := strings.ToLower(m.filter)
 
+	// "@fragment" matches working directories only
+	pathFilter, pathOnly := strings.CutPrefix(filterLower, pathFilterPrefix)
+
+	shown := m.shownSessionCount()
 	for i, session := range m.sessions {
-		// Apply fuzzy filter if active
-		if m.filter != "" && !fuzzyMatch(session.Name, filterLower) {
-			continue
+		// Sessions are sorted by activity (starred first), so the cap keeps the most recent ones
+		if m.filter == "" && i >= shown {
+			break
+		}
+
+		// Apply fuzzy filter if active - a session matches by its own name,
+		// the name of any of its windows, or its working directory
+		// (sessions whose path isn't resolved yet match by name only)
+		var matchingWindows []int
+		if pathOnly {
+			if pathFilter != "" && !fuzzyMatch(m.sessionPaths[session.Name], pathFilter) {
+				continue
+			}
+		} else if m.filter != "" {
+			for j, window := range session.Windows {
+				if fuzzyMatch(window.Name, filterLower) {
+					matchingWindows = append(matchingWindows, j)
+				}
+			}
+			// Short filters would match nearly every path, so paths need minPathFilter chars
+			pathMatch := len(filterLower) >= minPathFilter && fuzzyMatch(m.sessionPaths[session.Name], filterLower)
+			if !fuzzyMatch(session.Name, filterLower) && len(matchingWindows) == 0 && !pathMatch {
+				continue
+			}
 		}
 
 		m.items = append(m.items, Item{
@@ -1801,6 +4397,17 @@ func (m *Model) rebuildItems() {
 			SessionIndex: i,
 		})
 
+		// Collapsed sessions still show windows that match the filter
+		if !session.Expanded {
+			for _, j := range matchingWindows {
+				m.items = append(m.items, Item{
+					Type:         ItemTypeWindow,
+					SessionIndex: i,
+					WindowIndex:  j,
+				})
+			}
+		}
+
 		if session.Expanded {
 			for j, window := range session.Windows {
 				m.items = append(m.items, Item{
@@ -1824,6 +4431,16 @@ func (m *Model) rebuildItems() {
 		}
 	}
 
+	// Recent projects without a session follow the sessions (only when not
+	// filtering), so the cursor still starts on the most recent session
+	m.recentRows = nil
+	if m.filter == "" {
+		m.recentRows = m.recentProjects
+	}
+	for i := range m.recentRows {
+		m.items = append(m.items, Item{Type: ItemTypeRecent, RecentIndex: i})
+	}
+
 	// Ensure cursor is in bounds
 	if m.cursor >= len(m.items) {
 		m.cursor = len(m.items) - 1
@@ -1845,16 +4462,63 @@ func (m *Model) updateScrollOffset() {
 	if m.cursor >= m.scrollOffset+maxVisible {
 		m.scrollOffset = m.cursor - maxVisible + 1
 	}
+	// With row_spacing the blank lines between sessions leave room for fewer items
+	for m.scrollOffset < m.cursor && m.cursor >= m.sessionVisibleEnd(m.scrollOffset) {
+		m.scrollOffset++
+	}
 	// Ensure scroll offset is not negative
 	if m.scrollOffset < 0 {
 		m.scrollOffset = 0
 	}
 }
 
+// fitToSize re-runs the scroll math of every list after a resize, so the cursor
+// stays visible and a taller window fills up with rows above the old offset
+func (m *Model) fitToSize() {
+	for m.scrollOffset > 0 && m.sessionVisibleEnd(m.scrollOffset-1) >= len(m.items) {
+		m.scrollOffset--
+	}
+	m.updateScrollOffset()
+
+	if m.projectList != nil {
+		m.projectList.SetHeight(m.projectMaxVisibleItems())
+	}
+	if m.cloneList != nil {
+		m.cloneList.SetHeight(m.cloneMaxVisibleItems())
+	}
+	if m.moveList != nil {
+		m.moveList.SetHeight(m.projectMaxVisibleItems())
+	}
+	if m.bookmarkList != nil {
+		m.bookmarkList.SetHeight(m.bookmarkMaxVisibleItems())
+	}
+}
+
+// titleBar renders the title bar with the mode name in its accent color
+func (m *Model) titleBar() string {
+	return ui.RenderTitleBar("HELM", m.mode.String(), m.mode.Accent(), m.appWidth())
+}
+
+// appWidth returns the outer width of the app, clamped to min_width/max_width
+// Returns 0 while the terminal size is still unknown
+func (m *Model) appWidth() int {
+	w := m.width
+	if w <= 0 {
+		return 0
+	}
+	if m.config.MaxWidth > 0 && w > m.config.MaxWidth {
+		w = m.config.MaxWidth
+	}
+	if m.config.MinWidth > 0 && w < m.config.MinWidth {
+		w = m.config.MinWidth
+	}
+	return w
+}
+
 // contentWidth returns the available width inside the app border/padding
 func (m *Model) contentWidth() int {
-	if m.width > 0 {
-		return m.width - ui.AppBorderOverheadX
+	if w := m.appWidth(); w > 0 {
+		return w - ui.AppBorderOverheadX
 	}
 	return 56 // Default fallback (60 - 4)
 }
@@ -1877,15 +4541,28 @@ func (m *Model) rowWidth() int {
 	return m.contentWidth() - ui.ScrollbarColumnWidth
 }
 
+// footerPadding returns the blank lines between contentLines of content and the
+// footer, so every view puts its footer on the last lines
+func (m *Model) footerPadding(contentLines int) string {
+	contentH := m.contentHeight()
+	if contentH <= 0 {
+		return ""
+	}
+	return strings.Repeat("\n", max(0, contentH-ui.HeaderOverhead-contentLines-ui.FooterOverhead))
+}
+
 // sessionMaxVisibleItems returns the actual number of session items that can be shown
 // based on window height, accounting for fixed UI elements
 func (m *Model) sessionMaxVisibleItems() int {
 	contentH := m.contentHeight()
 	if contentH > 0 {
 		overhead := ui.BaseOverhead
-		if m.sessionsLoaded && len(m.items) > 0 {
+		if m.sessionsLoaded && len(m.items) > 0 && !m.compact {
 			overhead = ui.WithTableHeaderOverhead
 		}
+		if m.showsCurrentRow() {
+			overhead++
+		}
 		if available := contentH - overhead; available > 0 {
 			return available
 		}
@@ -1893,6 +4570,52 @@ func (m *Model) sessionMaxVisibleItems() int {
 	return ui.DefaultVisibleItems
 }
 
+// sessionVisibleEnd returns the end (exclusive) of the items shown from offset:
+// as many as fit in sessionMaxVisibleItems lines, counting row_spacing blank lines
+func (m *Model) sessionVisibleEnd(offset int) int {
+	lines := m.sessionMaxVisibleItems()
+	end := max(offset, 0)
+	for end < len(m.items) {
+		cost := 1 + m.spacingBefore(end, offset) + m.groupHeaderBefore(end)
+		if cost > lines {
+			break
+		}
+		lines -= cost
+		end++
+	}
+	return end
+}
+
+// spacingBefore returns the blank lines drawn above item i when offset is the
+// first item shown: row_spacing before every session (or recent project) row but the first
+func (m *Model) spacingBefore(i, offset int) int {
+	if m.config.RowSpacing <= 0 || i == offset {
+		return 0
+	}
+	if t := m.items[i].Type; t == ItemTypeSession || t == ItemTypeRecent {
+		return m.config.RowSpacing
+	}
+	return 0
+}
+
+// groupHeaderBefore returns the lines of the group header drawn above item i:
+// one above each row standing for a tmux session group (none in compact mode)
+func (m *Model) groupHeaderBefore(i int) int {
+	if m.compact || m.items[i].Type != ItemTypeSession {
+		return 0
+	}
+	if m.sessions[m.items[i].SessionIndex].GroupSize > 1 {
+		return 1
+	}
+	return 0
+}
+
+// showsCurrentRow reports whether the "you are here" row is drawn above the list
+// It is hidden while filtering since the current session can't be selected
+func (m *Model) showsCurrentRow() bool {
+	return m.currentInfo != nil && m.filter == ""
+}
+
 // projectMaxVisibleItems returns the actual number of items that can be shown
 // based on window height, matching the View's calculation
 func (m *Model) projectMaxVisibleItems() int {
@@ -1905,6 +4628,17 @@ func (m *Model) projectMaxVisibleItems() int {
 	return ui.DefaultVisibleItems
 }
 
+// bookmarkMaxVisibleItems returns the number of bookmarks that fit below the table header
+func (m *Model) bookmarkMaxVisibleItems() int {
+	contentH := m.contentHeight()
+	if contentH > 0 {
+		if available := contentH - ui.WithTableHeaderOverhead; available > 0 {
+			return available
+		}
+	}
+	return ui.DefaultVisibleItems
+}
+
 // cloneMaxVisibleItems returns the actual number of items that can be shown
 // in the clone repo view based on window height
 func (m *Model) cloneMaxVisibleItems() int {
@@ -1930,6 +4664,9 @@ func (m *Model) isCursorValid() bool {
 
 // getTargetName returns the tmux target name for the given item
 func (m *Model) getTargetName(item Item) string {
+	if item.Type == ItemTypeRecent {
+		return m.recentRows[item.RecentIndex]
+	}
 	session := m.sessions[item.SessionIndex]
 	switch item.Type {
 	case ItemTypeSession:
@@ -1946,103 +4683,327 @@ func (m *Model) getTargetName(item Item) string {
 	}
 }
 
+// pluralize formats a count with a singular or plural noun ("1 client", "2 clients")
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
 // setError sets an error message on the model
 func (m *Model) setError(format string, args ...any) {
 	m.message = fmt.Sprintf(format, args...)
 	m.messageIsError = true
+	m.logMessage(m.message, true)
 }
 
 // setMessage sets a non-error message on the model
 func (m *Model) setMessage(format string, args ...any) {
 	m.message = fmt.Sprintf(format, args...)
 	m.messageIsError = false
+	m.logMessage(m.message, false)
+}
+
+// maxLogEntries caps the message log ring buffer
+const maxLogEntries = 50
+
+// logEntry is a status or error message kept for the message log view
+type logEntry struct {
+	Time    time.Time
+	Text    string
+	IsError bool
+}
+
+// logMessage records a message in the log, dropping the oldest past maxLogEntries
+func (m *Model) logMessage(text string, isError bool) {
+	m.messages = append([]logEntry{{Time: time.Now(), Text: text, IsError: isError}}, m.messages...)
+	if len(m.messages) > maxLogEntries {
+		m.messages = m.messages[:maxLogEntries]
+	}
+}
+
+// newSessionName expands new_session_template to pre-fill the create input
+// {n} counts up from 1 until the name doesn't collide with an existing session
+func (m Model) newSessionName(now time.Time) string {
+	tmpl := m.config.NewSessionTemplate
+	if tmpl == "" {
+		return ""
+	}
+	tmpl = strings.NewReplacer("{date}", now.Format("2006-01-02"), "{time}", now.Format("1504")).Replace(tmpl)
+	if !strings.Contains(tmpl, "{n}") {
+		return sanitizeSessionName(tmpl)
+	}
+
+	taken := m.openSessionNames()
+	for n := 1; ; n++ {
+		name := sanitizeSessionName(strings.ReplaceAll(tmpl, "{n}", strconv.Itoa(n)))
+		if !taken[name] {
+			return name
+		}
+	}
+}
+
+// sanitizeSessionName converts a path to a valid tmux session name
+// Dots and colons have special meaning in tmux target syntax (window.pane, session:window)
+// Spaces cause issues with shell commands
+func sanitizeSessionName(name string) string {
+	replacer := strings.NewReplacer(
+		"/", "-",
+		".", "-",
+		":", "-",
+		" ", "-",
+	)
+	return replacer.Replace(name)
+}
+
+// tooSmall returns true if the terminal is known and below the minimum usable size
+func (m *Model) tooSmall() bool {
+	if m.width <= 0 || m.height <= 0 {
+		return false
+	}
+	return m.width < ui.MinUsableWidth || m.height < ui.MinUsableHeight
+}
+
+// View implements tea.Model
+func (m Model) View() string {
+	if m.tooSmall() {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			ui.ErrorMessageStyle.Render("Terminal too small"))
+	}
+
+	var view string
+	switch m.mode {
+	case ModePickDirectory, ModeConfirmRemoveFolder:
+		view = m.viewPickDirectory()
+	case ModeCloneRepo:
+		view = m.viewCloneRepo()
+	case ModeBookmarks:
+		view = m.viewBookmarks()
+	case ModeCreatePath:
+		view = m.viewCreatePath()
+	case ModeMoveWindow:
+		view = m.viewMoveWindow()
+	case ModeLog:
+		view = m.viewLog()
+	case ModeSettings:
+		view = m.viewSettings()
+	default:
+		view = m.viewSessionList()
+	}
+
+	// Center the app when max_width leaves the terminal wider than the app
+	if m.width > m.appWidth() {
+		return lipgloss.PlaceHorizontal(m.width, lipgloss.Center, view)
+	}
+	return view
+}
+
+// viewCreatePath renders the path input view for creating sessions at arbitrary paths
+func (m Model) viewCreatePath() string {
+	var b strings.Builder
+
+	// Fixed header: title bar + prompt + border
+	b.WriteString(m.titleBar())
+	b.WriteString("\n")
+
+	// Path input line
+	b.WriteString(ui.RenderPrompt(m.pathInput.View(), m.appWidth()))
+	b.WriteString("\n")
+
+	b.WriteString(ui.RenderBorder(m.borderWidth()))
+	b.WriteString("\n")
+
+	// Content area - show completions
+	contentLines := 0
+
+	if len(m.pathCompletions) > 0 {
+		b.WriteString("  Completions (Tab to complete):\n")
+		contentLines++
+		maxShow := 8
+		if len(m.pathCompletions) < maxShow {
+			maxShow = len(m.pathCompletions)
+		}
+		for i := 0; i < maxShow; i++ {
+			b.WriteString("    " + m.pathCompletions[i] + "\n")
+			contentLines++
+		}
+		if len(m.pathCompletions) > maxShow {
+			b.WriteString(fmt.Sprintf("    ... and %d more\n", len(m.pathCompletions)-maxShow))
+			contentLines++
+		}
+	} else {
+		b.WriteString("  Enter path for new session\n")
+		contentLines++
+		b.WriteString("  (folder will be created if it doesn't exist)\n")
+		contentLines++
+	}
+
+	// Pad to push the footer to the bottom
+	b.WriteString(m.footerPadding(contentLines))
+
+	// Fixed footer
+	stateText := fmt.Sprintf("Create session: %s", m.pendingSessionName)
+	hints := ui.HelpCreatePath()
+	b.WriteString(ui.RenderFooter(m.message, stateText, hints, m.messageIsError, m.appWidth()))
+
+	return ui.AppStyle.Render(b.String())
 }
 
-// sanitizeSessionName converts a path to a valid tmux session name
-// Dots and colons have special meaning in tmux target syntax (window.pane, session:window)
-// Spaces cause issues with shell commands
-func sanitizeSessionName(name string) string {
-	replacer := strings.NewReplacer(
-		"/", "-",
-		".", "-",
-		":", "-",
-		" ", "-",
-	)
-	return replacer.Replace(name)
+// viewLog renders the message log, newest first
+func (m Model) viewLog() string {
+	var b strings.Builder
+
+	// Fixed header: title bar + prompt + border
+	b.WriteString(m.titleBar())
+	b.WriteString("\n")
+	b.WriteString(ui.RenderPrompt("", m.appWidth()))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderBorder(m.borderWidth()))
+	b.WriteString("\n")
+
+	maxItems := m.projectMaxVisibleItems()
+	end := min(m.logOffset+maxItems, len(m.messages))
+	visible := m.messages[min(m.logOffset, end):end]
+	scrollbar := ui.ScrollbarChars(len(m.messages), maxItems, m.logOffset, len(visible))
+
+	contentLines := 0
+	for i, entry := range visible {
+		if i < len(scrollbar) {
+			b.WriteString(scrollbar[i])
+			b.WriteString(" ")
+		}
+		b.WriteString(ui.TimeStyle.Render(entry.Time.Format("15:04:05")))
+		b.WriteString(" ")
+		// Scrollbar column and "15:04:05 " come before the text
+		text := ui.TruncateLine(entry.Text, m.contentWidth()-ui.ScrollbarColumnWidth-9)
+		if entry.IsError {
+			b.WriteString(ui.ErrorTextStyle.Render(text))
+		} else {
+			b.WriteString(text)
+		}
+		b.WriteString("\n")
+		contentLines++
+	}
+
+	if len(m.messages) == 0 {
+		b.WriteString("  No messages yet\n")
+		contentLines++
+	}
+
+	// Pad to push the footer to the bottom
+	b.WriteString(m.footerPadding(contentLines))
+
+	stateText := pluralize(len(m.messages), "message")
+	b.WriteString(ui.RenderFooter("", stateText, ui.HelpMessageLog(), false, m.appWidth()))
+
+	return ui.AppStyle.Render(b.String())
 }
 
-// View implements tea.Model
-func (m Model) View() string {
-	if m.mode == ModePickDirectory || m.mode == ModeConfirmRemoveFolder {
-		return m.viewPickDirectory()
-	}
-	if m.mode == ModeCloneRepo {
-		return m.viewCloneRepo()
-	}
-	if m.mode == ModeBookmarks {
-		return m.viewBookmarks()
+// viewSettings renders the settings list with each option's current value
+func (m Model) viewSettings() string {
+	var b strings.Builder
+
+	// Fixed header: title bar + prompt + border
+	b.WriteString(m.titleBar())
+	b.WriteString("\n")
+	b.WriteString(ui.RenderPrompt("", m.appWidth()))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderBorder(m.borderWidth()))
+	b.WriteString("\n")
+
+	keyWidth := 0
+	for _, s := range config.Settings {
+		keyWidth = max(keyWidth, len(s.Key))
 	}
-	if m.mode == ModeCreatePath {
-		return m.viewCreatePath()
+
+	// Keep the cursor in view when the list is taller than the popup
+	maxItems := m.projectMaxVisibleItems()
+	offset := max(0, m.settingsCursor-maxItems+1)
+	end := min(offset+maxItems, len(config.Settings))
+
+	contentLines := 0
+	for i := offset; i < end; i++ {
+		s := config.Settings[i]
+		value := m.config.SettingValue(s.Key)
+		switch value {
+		case "true":
+			value = "on"
+		case "false":
+			value = "off"
+		}
+		line := fmt.Sprintf("%-*s  %-4s %s", keyWidth, s.Key, value, ui.TimeStyle.Render(s.Description))
+		line = ui.TruncateLine(line, m.rowWidth()-2) // Inside the row style's padding
+		if i == m.settingsCursor {
+			b.WriteString(ui.SessionSelectedStyle.Width(m.rowWidth()).Render(line))
+		} else {
+			b.WriteString(ui.SessionStyle.Width(m.rowWidth()).Render(line))
+		}
+		b.WriteString("\n")
+		contentLines++
 	}
-	return m.viewSessionList()
+
+	// Pad to push the footer to the bottom
+	b.WriteString(m.footerPadding(contentLines))
+
+	stateText := "Saved to " + config.SettingsPath()
+	b.WriteString(ui.RenderFooter(m.message, stateText, ui.HelpSettings(!m.config.DisablePopups), m.messageIsError, m.appWidth()))
+
+	return ui.AppStyle.Render(b.String())
 }
 
-// viewCreatePath renders the path input view for creating sessions at arbitrary paths
-func (m Model) viewCreatePath() string {
+// viewMoveWindow renders the target session picker for moving/linking a window
+func (m Model) viewMoveWindow() string {
 	var b strings.Builder
 
 	// Fixed header: title bar + prompt + border
-	b.WriteString(ui.RenderTitleBar("HELM", m.mode.String(), m.width))
+	b.WriteString(m.titleBar())
 	b.WriteString("\n")
 
-	// Path input line
-	b.WriteString(ui.RenderPrompt(m.pathInput.View(), m.width))
+	filter := m.moveList.Filter()
+	b.WriteString(ui.RenderPrompt(filter, m.appWidth()))
 	b.WriteString("\n")
 
 	b.WriteString(ui.RenderBorder(m.borderWidth()))
 	b.WriteString("\n")
 
-	// Content area - show completions
-	contentLines := 0
+	maxItems := m.projectMaxVisibleItems()
+	m.moveList.SetHeight(maxItems)
 
-	if len(m.pathCompletions) > 0 {
-		b.WriteString("  Completions (Tab to complete):\n")
-		contentLines++
-		maxShow := 8
-		if len(m.pathCompletions) < maxShow {
-			maxShow = len(m.pathCompletions)
+	visibleItems := m.moveList.VisibleItems()
+	scrollOffset := m.moveList.ScrollOffset()
+	scrollbar := ui.ScrollbarChars(m.moveList.Len(), maxItems, scrollOffset, len(visibleItems))
+
+	contentLines := 0
+	for i, name := range visibleItems {
+		if i < len(scrollbar) {
+			b.WriteString(scrollbar[i])
+			b.WriteString(" ")
 		}
-		for i := 0; i < maxShow; i++ {
-			b.WriteString("    " + m.pathCompletions[i] + "\n")
-			contentLines++
+
+		label := name
+		if name == m.currentSession {
+			label += " (current)"
 		}
-		if len(m.pathCompletions) > maxShow {
-			b.WriteString(fmt.Sprintf("    ... and %d more\n", len(m.pathCompletions)-maxShow))
-			contentLines++
+		if m.moveList.IsSelected(scrollOffset + i) {
+			b.WriteString(ui.FilterStyle.Render(label))
+		} else {
+			b.WriteString(label)
 		}
-	} else {
-		b.WriteString("  Enter path for new session\n")
-		contentLines++
-		b.WriteString("  (folder will be created if it doesn't exist)\n")
+		b.WriteString("\n")
 		contentLines++
 	}
 
-	// Add padding to push footer to bottom
-	headerLines := ui.HeaderOverhead
-	footerLines := ui.FooterOverhead
-	contentH := m.contentHeight()
-	if contentH > 0 {
-		padding := contentH - headerLines - contentLines - footerLines
-		for i := 0; i < padding; i++ {
-			b.WriteString("\n")
-		}
+	if m.moveList.Len() == 0 {
+		b.WriteString("  No sessions matching filter\n")
+		contentLines++
 	}
 
-	// Fixed footer
-	stateText := fmt.Sprintf("Create session: %s", m.pendingSessionName)
-	hints := ui.HelpCreatePath()
-	b.WriteString(ui.RenderFooter(m.message, stateText, hints, m.messageIsError, m.width))
+	// Pad to push the footer to the bottom
+	b.WriteString(m.footerPadding(contentLines))
+
+	stateText := fmt.Sprintf("Move window %s:%d to...", m.moveSourceName, m.moveSourceWindow)
+	b.WriteString(ui.RenderFooter(m.message, stateText, ui.HelpMoveWindow(), m.messageIsError, m.appWidth()))
 
 	return ui.AppStyle.Render(b.String())
 }
@@ -2052,12 +5013,12 @@ func (m Model) viewPickDirectory() string {
 	var b strings.Builder
 
 	// Fixed header: title bar + prompt + border
-	b.WriteString(ui.RenderTitleBar("HELM", m.mode.String(), m.width))
+	b.WriteString(m.titleBar())
 	b.WriteString("\n")
 
 	// Prompt line - show filter
 	filter := m.projectList.Filter()
-	b.WriteString(ui.RenderPrompt(filter, m.width))
+	b.WriteString(ui.RenderPrompt(filter, m.appWidth()))
 	b.WriteString("\n")
 
 	b.WriteString(ui.RenderBorder(m.borderWidth()))
@@ -2077,6 +5038,16 @@ func (m Model) viewPickDirectory() string {
 	// Get scrollbar characters for each line
 	scrollbar := ui.ScrollbarChars(totalItems, maxItems, scrollOffset, len(visibleItems))
 
+	// With several project_dirs, prefix each entry with its (dim) root so
+	// identical leaf names from different roots can be told apart
+	rootWidth := 0
+	if len(m.config.ProjectDirs) > 1 {
+		for _, fullPath := range visibleItems {
+			rootWidth = max(rootWidth, len(m.projectRootLabel(fullPath)))
+		}
+	}
+
+	open := m.openSessionNames()
 	contentLines := 0
 	for i, fullPath := range visibleItems {
 		displayPath := m.extractDisplayPath(fullPath)
@@ -2088,11 +5059,21 @@ func (m Model) viewPickDirectory() string {
 			b.WriteString(" ")
 		}
 
+		if rootWidth > 0 {
+			b.WriteString(ui.ProjectRootStyle.Render(fmt.Sprintf("%-*s", rootWidth, m.projectRootLabel(fullPath))))
+			b.WriteString(" ")
+		}
+
 		if selected {
 			b.WriteString(ui.FilterStyle.Render(displayPath))
 		} else {
 			b.WriteString(displayPath)
 		}
+		// Selecting an open entry switches instead of creating (createSessionFromDir)
+		if open[m.extractSessionName(fullPath)] {
+			b.WriteString(" ")
+			b.WriteString(ui.OpenSessionStyle.Render(ui.OpenSessionTag))
+		}
 		b.WriteString("\n")
 		contentLines++
 	}
@@ -2107,18 +5088,8 @@ func (m Model) viewPickDirectory() string {
 		contentLines++
 	}
 
-	// Add padding to push footer to bottom
-	// Fixed header: 3 lines (title + prompt + border)
-	// Fixed footer: 5 lines (border + notification + state + hints(2))
-	headerLines := ui.HeaderOverhead
-	footerLines := ui.FooterOverhead
-	contentH := m.contentHeight()
-	if contentH > 0 {
-		padding := contentH - headerLines - contentLines - footerLines
-		for i := 0; i < padding; i++ {
-			b.WriteString("\n")
-		}
-	}
+	// Pad to push the footer to the bottom
+	b.WriteString(m.footerPadding(contentLines))
 
 	// Fixed footer: notification + state + hints
 	var hints string
@@ -2133,11 +5104,11 @@ func (m Model) viewPickDirectory() string {
 		} else if filter != "" {
 			hints = ui.HelpFiltering()
 		} else {
-			hints = ui.HelpPickDirectory()
+			hints = ui.HelpPickDirectory(m.readOnly)
 		}
 	}
 
-	b.WriteString(ui.RenderFooter(m.message, m.stateText(), hints, m.messageIsError, m.width))
+	b.WriteString(ui.RenderFooter(m.message, m.stateText(), hints, m.messageIsError, m.appWidth()))
 
 	return ui.AppStyle.Render(b.String())
 }
@@ -2147,12 +5118,12 @@ func (m Model) viewCloneRepo() string {
 	var b strings.Builder
 
 	// Fixed header: title bar + prompt + border
-	b.WriteString(ui.RenderTitleBar("HELM", m.mode.String(), m.width))
+	b.WriteString(m.titleBar())
 	b.WriteString("\n")
 
 	// Prompt line - show filter
 	cloneFilter := m.cloneList.Filter()
-	b.WriteString(ui.RenderPrompt(cloneFilter, m.width))
+	b.WriteString(ui.RenderPrompt(cloneFilter, m.appWidth()))
 	b.WriteString("\n")
 
 	b.WriteString(ui.RenderBorder(m.borderWidth()))
@@ -2171,15 +5142,26 @@ func (m Model) viewCloneRepo() string {
 		contentLines++
 		b.WriteString("  Switch to the new session?\n")
 		contentLines++
-	} else if m.cloneLoading {
-		b.WriteString("  Fetching available repositories...\n")
-		contentLines++
 	} else if m.cloneCloning {
 		b.WriteString(fmt.Sprintf("  Cloning %s...\n", m.cloneCloningRepo))
 		contentLines++
 	} else if m.cloneError != "" {
 		b.WriteString(ui.ErrorMessageStyle.Render("  "+m.cloneError) + "\n")
 		contentLines++
+	} else if m.cloneURLMode {
+		if m.cloneNoGh {
+			b.WriteString("  GitHub CLI (gh) not found - type or paste a git URL to clone\n")
+		} else {
+			b.WriteString("  Type or paste a git URL to clone\n")
+		}
+		contentLines++
+		if ownerRepo := github.ParseGitURL(strings.TrimSpace(cloneFilter)); ownerRepo != "" {
+			b.WriteString(fmt.Sprintf("  → %s\n", filepath.Join(m.cloneBasePath, ownerRepo)))
+			contentLines++
+		}
+	} else if m.cloneLoading {
+		b.WriteString("  Fetching available repositories...\n")
+		contentLines++
 	} else if m.cloneList.Len() == 0 {
 		if cloneFilter != "" {
 			b.WriteString("  No repositories matching filter\n")
@@ -2211,29 +5193,31 @@ func (m Model) viewCloneRepo() string {
 			} else {
 				b.WriteString(repo)
 			}
+			if info, ok := m.cloneDetails[repo]; ok {
+				// Dim "[lang] description" after the name, cut to the remaining width
+				available := m.contentWidth() - ui.ScrollbarColumnWidth - len(repo) - 2
+				if detail := ui.RepoDetail(info.Language, info.Description, available); detail != "" {
+					b.WriteString("  ")
+					b.WriteString(ui.TimeStyle.Render(detail))
+				}
+			}
 			b.WriteString("\n")
 			contentLines++
 		}
 	}
 
-	// Add padding to push footer to bottom
-	// Fixed header: 3 lines (title + prompt + border)
-	// Fixed footer: 5 lines (border + notification + state + hints(2))
-	headerLines := ui.HeaderOverhead
-	footerLines := ui.FooterOverhead
-	contentH := m.contentHeight()
-	if contentH > 0 {
-		padding := contentH - headerLines - contentLines - footerLines
-		for i := 0; i < padding; i++ {
-			b.WriteString("\n")
-		}
-	}
+	// Pad to push the footer to the bottom
+	b.WriteString(m.footerPadding(contentLines))
 
 	// Fixed footer: notification + state + hints
 	var hints string
 	if m.cloneSuccess {
 		hints = ui.HelpCloneSuccess()
-	} else if m.cloneLoading || m.cloneCloning {
+	} else if m.cloneCloning {
+		hints = ui.HelpCloneRepoLoading()
+	} else if m.cloneURLMode {
+		hints = ui.HelpCloneURL(!m.cloneNoGh)
+	} else if m.cloneLoading {
 		hints = ui.HelpCloneRepoLoading()
 	} else if cloneFilter != "" {
 		hints = ui.HelpFiltering()
@@ -2241,7 +5225,7 @@ func (m Model) viewCloneRepo() string {
 		hints = ui.HelpCloneRepo()
 	}
 
-	b.WriteString(ui.RenderFooter(m.message, m.stateText(), hints, m.messageIsError, m.width))
+	b.WriteString(ui.RenderFooter(m.message, m.stateText(), hints, m.messageIsError, m.appWidth()))
 
 	return ui.AppStyle.Render(b.String())
 }
@@ -2252,9 +5236,9 @@ func (m Model) viewBookmarks() string {
 	filter := m.bookmarkList.Filter()
 
 	// Fixed header: title bar + prompt + border
-	b.WriteString(ui.RenderTitleBar("HELM", m.mode.String(), m.width))
+	b.WriteString(m.titleBar())
 	b.WriteString("\n")
-	b.WriteString(ui.RenderPrompt(filter, m.width))
+	b.WriteString(ui.RenderPrompt(filter, m.appWidth()))
 	b.WriteString("\n")
 	b.WriteString(ui.RenderBorder(m.borderWidth()))
 	b.WriteString("\n")
@@ -2272,15 +5256,7 @@ func (m Model) viewBookmarks() string {
 		}
 		contentLines++
 	} else {
-		// Calculate max visible items (includes table header)
-		contentH := m.contentHeight()
-		maxItems := ui.DefaultVisibleItems
-		if contentH > 0 {
-			if available := contentH - ui.WithTableHeaderOverhead; available > 0 {
-				maxItems = available
-			}
-		}
-		m.bookmarkList.SetHeight(maxItems)
+		m.bookmarkList.SetHeight(m.bookmarkMaxVisibleItems())
 
 		visibleBookmarks := m.bookmarkList.VisibleItems()
 		scrollOffset := m.bookmarkList.ScrollOffset()
@@ -2290,7 +5266,7 @@ func (m Model) viewBookmarks() string {
 		for _, bookmark := range visibleBookmarks {
 			// Check if session has git status
 			sessionName := m.extractSessionName(bookmark.Path)
-			if _, ok := m.gitStatuses[sessionName]; ok && m.config.GitStatusEnabled {
+			if _, ok := m.gitStatuses[sessionName]; ok && m.gitStatusEnabled() {
 				if ui.GitStatusColumnWidth > maxGitWidth {
 					maxGitWidth = ui.GitStatusColumnWidth
 				}
@@ -2300,6 +5276,8 @@ func (m Model) viewBookmarks() string {
 		layout := ui.RowLayout{
 			NameWidth:      m.maxNameWidth, // Use shared width for stable layout
 			GitStatusWidth: maxGitWidth,
+			IconWidth:      m.projectIconWidth(),
+			CountWidth:     m.windowCountWidth(),
 		}
 
 		// Table header row
@@ -2347,8 +5325,15 @@ func (m Model) viewBookmarks() string {
 						Expanded:       expanded,
 						LastActivity:   &lastActivity,
 						AnimFrame:      m.animationFrame,
+						ShowActivity:   m.config.ActivityIndicatorEnabled,
 					},
 				}
+				if m.config.SessionAgeEnabled {
+					created := session.Created
+					opts.Created = &created
+				}
+				opts.ProjectIcon = m.projectIcons[sessionName]
+				opts.WindowCount = session.WindowCount
 				if status, ok := m.gitStatuses[sessionName]; ok {
 					opts.GitStatus = &status
 				}
@@ -2357,6 +5342,7 @@ func (m Model) viewBookmarks() string {
 				}
 				if status, ok := m.claudeStatuses[sessionName]; ok {
 					opts.ClaudeStatus = &status
+					opts.ClaudeWait = m.config.ClaudeWaitDuration()
 				}
 				b.WriteString(ui.RenderSessionRow(sessionName, session.LastActivity, layout, opts, m.rowWidth()))
 				b.WriteString("\n")
@@ -2384,26 +5370,17 @@ func (m Model) viewBookmarks() string {
 		}
 	}
 
-	// Padding to push footer to bottom
-	// Fixed header: 3 lines, Fixed footer: 5 lines (border + notification + state + hints(2))
-	headerLines := ui.HeaderOverhead
-	footerLines := ui.FooterOverhead
-	contentH := m.contentHeight()
-	if contentH > 0 {
-		padding := contentH - headerLines - contentLines - footerLines
-		for i := 0; i < padding; i++ {
-			b.WriteString("\n")
-		}
-	}
+	// Pad to push the footer to the bottom
+	b.WriteString(m.footerPadding(contentLines))
 
 	// Fixed footer
 	var hints string
 	if filter != "" {
 		hints = ui.HelpFiltering()
 	} else {
-		hints = ui.HelpBookmarks()
+		hints = ui.HelpBookmarks(m.readOnly)
 	}
-	b.WriteString(ui.RenderFooter(m.message, m.stateText(), hints, m.messageIsError, m.width))
+	b.WriteString(ui.RenderFooter(m.message, m.stateText(), hints, m.messageIsError, m.appWidth()))
 
 	return ui.AppStyle.Render(b.String())
 }
@@ -2413,11 +5390,11 @@ func (m Model) viewSessionList() string {
 	var b strings.Builder
 
 	// Fixed header: title bar + prompt + border
-	b.WriteString(ui.RenderTitleBar("HELM", m.mode.String(), m.width))
+	b.WriteString(m.titleBar())
 	b.WriteString("\n")
 
 	// Prompt line - always show filter (input goes in notification line for create mode)
-	b.WriteString(ui.RenderPrompt(m.filter, m.width))
+	b.WriteString(ui.RenderPrompt(m.filter, m.appWidth()))
 	b.WriteString("\n")
 
 	b.WriteString(ui.RenderBorder(m.borderWidth()))
@@ -2427,16 +5404,24 @@ func (m Model) viewSessionList() string {
 	layout := ui.RowLayout{
 		NameWidth:      m.maxNameWidth,
 		GitStatusWidth: m.maxGitStatusWidth,
+		IconWidth:      m.projectIconWidth(),
+		CountWidth:     m.windowCountWidth(),
+		WatchWidth:     m.watchGlyphWidth(),
+		PaneMarkWidth:  m.paneMarkWidth(),
+		StarWidth:      m.starWidth(),
+		DeadWidth:      m.deadWidth(),
 	}
 
 	// Track content lines for padding calculation
 	contentLines := 0
 
-	// Table header row (only show when sessions are loaded)
-	if m.sessionsLoaded && len(m.items) > 0 {
+	// Table header row (only show when sessions are loaded, omitted in compact mode)
+	if m.sessionsLoaded && len(m.items) > 0 && !m.compact {
 		header := ui.RenderTableHeader(layout, ui.TableHeaderOpts{
 			ShowExpandIcon: true,
 			ShowTime:       true,
+			ShowActivity:   m.config.ActivityIndicatorEnabled,
+			ShowAge:        m.config.SessionAgeEnabled,
 			ShowGit:        m.maxGitStatusWidth > 0,
 			NameLabel:      "SESS",
 		})
@@ -2447,16 +5432,25 @@ func (m Model) viewSessionList() string {
 		contentLines += 2
 	}
 
-	// Session list (only visible items)
-	maxVisible := m.sessionMaxVisibleItems()
-	endIdx := m.scrollOffset + maxVisible
-	if endIdx > len(m.items) {
-		endIdx = len(m.items)
+	// Current session ("you are here") row, not part of the selectable items
+	if m.showsCurrentRow() {
+		b.WriteString("  ") // Align with scrollbar column
+		b.WriteString(ui.RenderCurrentSessionRow(m.currentSession, m.currentInfo.LastActivity, layout, m.rowWidth()))
+		b.WriteString("\n")
+		contentLines++
 	}
+
+	// Session list (only visible items)
+	endIdx := m.sessionVisibleEnd(m.scrollOffset)
 	visibleCount := endIdx - m.scrollOffset
+	listLines := visibleCount
+	for i := m.scrollOffset; i < endIdx; i++ {
+		listLines += m.spacingBefore(i, m.scrollOffset) + m.groupHeaderBefore(i)
+	}
 
-	// Get scrollbar characters for each line
-	scrollbar := ui.ScrollbarChars(len(m.items), maxVisible, m.scrollOffset, visibleCount)
+	// Get scrollbar characters for each line (row_spacing blank lines included)
+	scrollbar := ui.ScrollbarChars(len(m.items), visibleCount, m.scrollOffset, listLines)
+	lineIdx := 0
 
 	// Calculate session numbers (count sessions before visible area)
 	sessionNum := 0
@@ -2468,7 +5462,29 @@ func (m Model) viewSessionList() string {
 	for i := m.scrollOffset; i < endIdx; i++ {
 		item := m.items[i]
 		selected := i == m.cursor
-		lineIdx := i - m.scrollOffset
+
+		// Blank separator lines between sessions (row_spacing)
+		for range m.spacingBefore(i, m.scrollOffset) {
+			if lineIdx < len(scrollbar) {
+				b.WriteString(scrollbar[lineIdx])
+			}
+			b.WriteString("\n")
+			lineIdx++
+			contentLines++
+		}
+
+		// Header naming the tmux session group the next row stands for
+		if m.groupHeaderBefore(i) > 0 {
+			session := m.sessions[item.SessionIndex]
+			if lineIdx < len(scrollbar) {
+				b.WriteString(scrollbar[lineIdx])
+				b.WriteString(" ")
+			}
+			b.WriteString(ui.RenderGroupHeader(session.Group, session.GroupMembers, m.rowWidth()))
+			b.WriteString("\n")
+			lineIdx++
+			contentLines++
+		}
 
 		// Scrollbar on the left (styled when selected)
 		if lineIdx < len(scrollbar) {
@@ -2484,7 +5500,16 @@ func (m Model) viewSessionList() string {
 		case ItemTypeSession:
 			session := m.sessions[item.SessionIndex]
 
+			if m.compact {
+				opts := ui.RowOpts{Num: sessionNum, Name: session.Name, Selected: selected, Marked: m.isMarked(item), Popup: tmux.IsPopup(session.Name)}
+				b.WriteString(ui.RenderCompactSessionRow(session.Name, opts, m.rowWidth()))
+				sessionNum++
+				break
+			}
+
 			// Build options for this row
+			// Sessions showing filter-matched windows render as expanded
+			expanded := session.Expanded || (i+1 < len(m.items) && m.items[i+1].Type == ItemTypeWindow)
 			lastActivity := session.LastActivity
 			opts := ui.SessionRowOpts{
 				RowOpts: ui.RowOpts{
@@ -2492,11 +5517,30 @@ func (m Model) viewSessionList() string {
 					Name:           session.Name,
 					Selected:       selected,
 					ShowExpandIcon: true,
-					Expanded:       session.Expanded,
+					Expanded:       expanded,
 					LastActivity:   &lastActivity,
 					AnimFrame:      m.animationFrame,
+					ShowActivity:   m.config.ActivityIndicatorEnabled,
+					Marked:         m.isMarked(item),
+					Popup:          tmux.IsPopup(session.Name),
 				},
 			}
+			if m.config.SessionAgeEnabled {
+				created := session.Created
+				opts.Created = &created
+			}
+			opts.ProjectIcon = m.projectIcons[session.Name]
+			opts.WindowCount = session.WindowCount
+			opts.Env = m.envVersions[session.Name]
+			if m.config.PaneTitleEnabled {
+				opts.PaneInfo = paneInfo(session.PaneCommand, session.PaneTitle)
+			}
+			if session.Watched {
+				opts.Watch = m.config.WatchGlyph
+			}
+			opts.PaneMarked = session.PaneMarked
+			opts.Starred = m.starred[session.Name]
+			opts.DeadPanes = session.DeadPanes
 			if status, ok := m.gitStatuses[session.Name]; ok {
 				opts.GitStatus = &status
 			}
@@ -2505,23 +5549,30 @@ func (m Model) viewSessionList() string {
 			}
 			if status, ok := m.claudeStatuses[session.Name]; ok {
 				opts.ClaudeStatus = &status
+				opts.ClaudeWait = m.config.ClaudeWaitDuration()
 			}
 
-			b.WriteString(ui.RenderSessionRow(session.Name, session.LastActivity, layout, opts, m.rowWidth()))
+			b.WriteString(ui.RenderSessionRow(m.sessionDisplayName(session), session.LastActivity, layout, opts, m.rowWidth()))
 			sessionNum++
 
 		case ItemTypeWindow:
 			session := m.sessions[item.SessionIndex]
 			window := session.Windows[item.WindowIndex]
-			b.WriteString(ui.RenderWindowRow(window.Index, window.Name, ui.WindowRowOpts{Selected: selected, Expanded: window.Expanded}, m.rowWidth()))
+			b.WriteString(ui.RenderWindowRow(window.Index, window.Name, ui.WindowRowOpts{Selected: selected, Expanded: window.Expanded, Marked: m.isMarked(item)}, m.rowWidth()))
 
 		case ItemTypePane:
 			session := m.sessions[item.SessionIndex]
 			window := session.Windows[item.WindowIndex]
 			pane := window.Panes[item.PaneIndex]
-			b.WriteString(ui.RenderPaneRow(pane.Index, pane.Command, pane.Active, ui.PaneRowOpts{Selected: selected}, m.rowWidth()))
+			b.WriteString(ui.RenderPaneRow(pane.Index, pane.Command, pane.Active, ui.PaneRowOpts{Selected: selected, Marked: m.isMarked(item), Dead: pane.Dead}, m.rowWidth()))
+
+		case ItemTypeRecent:
+			num := m.shownSessionCount() + item.RecentIndex
+			name := m.extractSessionName(m.recentRows[item.RecentIndex])
+			b.WriteString(ui.RenderRecentProjectRow(num, name, layout, selected, m.rowWidth()))
 		}
 		b.WriteString("\n")
+		lineIdx++
 		contentLines++
 	}
 
@@ -2535,18 +5586,8 @@ func (m Model) viewSessionList() string {
 		contentLines++
 	}
 
-	// Add padding to push footer to bottom
-	// Fixed header: 3 lines (title + prompt + border)
-	// Fixed footer: 5 lines (border + notification + state + hints(2))
-	headerLines := ui.HeaderOverhead
-	footerLines := ui.FooterOverhead
-	contentH := m.contentHeight()
-	if contentH > 0 {
-		padding := contentH - headerLines - contentLines - footerLines
-		for i := 0; i < padding; i++ {
-			b.WriteString("\n")
-		}
-	}
+	// Pad to push the footer to the bottom
+	b.WriteString(m.footerPadding(contentLines))
 
 	// Fixed footer: notification + state + hints
 	var hints string
@@ -2554,6 +5595,9 @@ func (m Model) viewSessionList() string {
 	switch m.mode {
 	case ModeNormal:
 		notification = m.message
+		if notification == "" {
+			notification = ui.TimeStyle.Render(m.selectionDetail())
+		}
 		if m.filter != "" {
 			if len(m.items) == 0 {
 				hints = ui.HelpFilteringNoResults()
@@ -2561,36 +5605,269 @@ func (m Model) viewSessionList() string {
 				hints = ui.HelpFiltering()
 			}
 		} else {
-			hints = ui.HelpNormal()
+			hints = ui.HelpNormal(ui.DefaultKeyMap, ui.HelpFeatures{
+				ReadOnly: m.readOnly,
+				Projects: len(m.config.ProjectDirs) > 0,
+				GitHub:   m.ghInstalled,
+				Popups:   !m.config.DisablePopups,
+			}, m.contentWidth()-2) // Inside the footer's padding
 		}
 	case ModeConfirmKill:
 		notification = m.message
-		hints = ui.HelpConfirmKill()
+		hints = ui.HelpConfirmKill(m.killRemovePath == "" && len(m.selected) == 0 && m.isCursorValid() && m.items[m.cursor].Type == ItemTypeSession)
 	case ModeCreate:
 		notification = "New session: " + m.input.View()
 		hints = ui.HelpCreate()
+	case ModeConfirmQuit:
+		notification = m.message
+		hints = ui.HelpConfirmQuit()
+	case ModeConfirmClearClaude:
+		notification = m.message
+		hints = ui.HelpConfirmClearClaude()
+	case ModeBroadcast:
+		if m.broadcastCommand != "" {
+			notification = m.message
+		} else {
+			notification = "Send: " + m.input.View()
+		}
+		hints = ui.HelpBroadcast(m.broadcastCommand != "")
+	case ModeGoTo:
+		notification = "Go to: " + m.gotoInput + "_"
+		hints = ui.HelpGoTo()
 	}
 
-	b.WriteString(ui.RenderFooter(notification, m.stateText(), hints, m.messageIsError, m.width))
+	b.WriteString(ui.RenderFooter(notification, m.stateText(), hints, m.messageIsError, m.appWidth()))
 
 	return ui.AppStyle.Render(b.String())
 }
 
-// sessionCachePath returns the path to the session cache file
+// maxRecentHistory caps how many project paths are kept in the recent history file
+const maxRecentHistory = 20
+
+// recentHistoryPath returns the path to the recent projects history file
+func (m *Model) recentHistoryPath() string {
+	return filepath.Join(m.config.CacheDir, "recent_projects.json")
+}
+
+// loadRecentHistory reads the recent project paths, newest first
+func (m *Model) loadRecentHistory() []string {
+	data, err := os.ReadFile(m.recentHistoryPath())
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	if err := json.Unmarshal(data, &paths); err != nil {
+		return nil
+	}
+	return paths
+}
+
+// recordRecentProject moves path to the front of the recent history and persists it
+func (m *Model) recordRecentProject(path string) {
+	if m.config.RecentProjects <= 0 {
+		return
+	}
+	history := []string{path}
+	for _, p := range m.recentHistory {
+		if p != path && len(history) < maxRecentHistory {
+			history = append(history, p)
+		}
+	}
+	m.recentHistory = history
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(m.config.CacheDir, 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(m.recentHistoryPath(), data, 0644)
+}
+
+// recentProjectsWithoutSession returns up to recent_projects history paths that
+// have no session yet (neither listed nor the current one) and still exist
+func (m *Model) recentProjectsWithoutSession() []string {
+	if m.config.RecentProjects <= 0 {
+		return nil
+	}
+	open := map[string]bool{m.currentSession: true}
+	for _, s := range m.sessions {
+		open[s.Name] = true
+	}
+
+	var rows []string
+	for _, path := range m.recentHistory {
+		if len(rows) >= m.config.RecentProjects {
+			break
+		}
+		if open[m.extractSessionName(path)] {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		rows = append(rows, path)
+	}
+	return rows
+}
+
+// maxCreateHistory caps how many create prompt inputs are kept
+const maxCreateHistory = 50
+
+// createHistoryPath returns the path to the create prompt history file
+func (m *Model) createHistoryPath() string {
+	return filepath.Join(m.config.CacheDir, "create_history.json")
+}
+
+// loadCreateHistory reads the create prompt history, newest first
+func (m *Model) loadCreateHistory() []string {
+	data, err := os.ReadFile(m.createHistoryPath())
+	if err != nil {
+		return nil
+	}
+	var inputs []string
+	if err := json.Unmarshal(data, &inputs); err != nil {
+		return nil
+	}
+	return inputs
+}
+
+// recordCreateHistory moves input to the front of the create history and persists it
+func (m *Model) recordCreateHistory(input string) {
+	history := []string{input}
+	for _, h := range m.createHistory {
+		if h != input && len(history) < maxCreateHistory {
+			history = append(history, h)
+		}
+	}
+	m.createHistory = history
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(m.config.CacheDir, 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(m.createHistoryPath(), data, 0644)
+}
+
+// starredPath returns the path to the favorite sessions file of the server
+func (m *Model) starredPath() string {
+	return m.serverCachePath("starred")
+}
+
+// loadStarred reads the favorite session names
+func (m *Model) loadStarred() map[string]bool {
+	starred := make(map[string]bool)
+	data, err := os.ReadFile(m.starredPath())
+	if err != nil {
+		return starred
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return starred
+	}
+	for _, name := range names {
+		starred[name] = true
+	}
+	return starred
+}
+
+// saveStarred persists the favorite session names, sorted for a stable file
+func (m *Model) saveStarred() {
+	names := make([]string, 0, len(m.starred))
+	for name := range m.starred {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	data, err := json.Marshal(names)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(m.config.CacheDir, 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(m.starredPath(), data, 0644)
+}
+
+// sortStarred moves starred sessions to the top, keeping activity order within
+// both groups. Stars are kept by name, so they survive reloads and restarts
+func (m *Model) sortStarred() {
+	if len(m.starred) == 0 {
+		return
+	}
+	sort.SliceStable(m.sessions, func(i, j int) bool {
+		return m.starred[m.sessions[i].Name] && !m.starred[m.sessions[j].Name]
+	})
+}
+
+// toggleStar stars or unstars the session under the cursor (or of the window or
+// pane under it) and re-sorts, keeping the cursor on that session
+func (m *Model) toggleStar() {
+	if !m.isCursorValid() || m.items[m.cursor].Type == ItemTypeRecent {
+		return
+	}
+	name := m.sessions[m.items[m.cursor].SessionIndex].Name
+	if m.starred[name] {
+		delete(m.starred, name)
+		m.setMessage("Unstarred %s", name)
+	} else {
+		if m.starred == nil {
+			m.starred = make(map[string]bool)
+		}
+		m.starred[name] = true
+		m.setMessage("Starred %s", name)
+	}
+	m.saveStarred()
+
+	// Back to activity order first, so an unstarred session returns to its place
+	sort.SliceStable(m.sessions, func(i, j int) bool {
+		return m.sessions[i].LastActivity.After(m.sessions[j].LastActivity)
+	})
+	m.sortStarred()
+	m.rebuildItems()
+	m.restoreCursor(name)
+	m.updateScrollOffset()
+}
+
+// sessionCachePath returns the path to the session cache file of the server
 func (m *Model) sessionCachePath() string {
-	return filepath.Join(m.config.CacheDir, "sessions.json")
+	return m.serverCachePath("sessions")
+}
+
+// serverCachePath returns the path to the cache file name.json, suffixed with
+// the configured socket so each tmux server keeps its own sessions and stars
+func (m *Model) serverCachePath(name string) string {
+	if m.config.Socket != "" {
+		name += "-" + strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' {
+				return r
+			}
+			return '_'
+		}, m.config.Socket)
+	}
+	return filepath.Join(m.config.CacheDir, name+".json")
 }
 
 // cachedSession is a simplified session for caching (excludes UI state)
 type cachedSession struct {
 	Name         string    `json:"name"`
 	LastActivity time.Time `json:"last_activity"`
+	WindowCount  int       `json:"window_count,omitempty"`
 }
 
 // sessionCache wraps cached sessions with layout metadata for stable column widths
 type sessionCache struct {
 	Sessions     []cachedSession `json:"sessions"`
-	MaxNameWidth int             `json:"max_name_width"` // Persisted to prevent layout shift
+	MaxNameWidth int             `json:"max_name_width"`         // Persisted to prevent layout shift
+	NameDisplay  string          `json:"name_display,omitempty"` // name_display MaxNameWidth was measured with
+}
+
+// nameDisplay returns name_display with the default filled in
+func (m *Model) nameDisplay() string {
+	return cmp.Or(m.config.NameDisplay, config.NameDisplayTmux)
 }
 
 // loadSessionCache loads cached sessions from disk
@@ -2604,12 +5881,17 @@ func (m *Model) loadSessionCache() []tmux.Session {
 	// Try new format first (with layout metadata)
 	var cache sessionCache
 	if err := json.Unmarshal(data, &cache); err == nil && len(cache.Sessions) > 0 {
-		m.maxNameWidth = cache.MaxNameWidth
+		// Paths are wider than tmux names, so a width from the other mode (or
+		// from before the mode was recorded) is dropped
+		if cache.NameDisplay == m.nameDisplay() {
+			m.maxNameWidth = cache.MaxNameWidth
+		}
 		sessions := make([]tmux.Session, len(cache.Sessions))
 		for i, c := range cache.Sessions {
 			sessions[i] = tmux.Session{
 				Name:         c.Name,
 				LastActivity: c.LastActivity,
+				WindowCount:  c.WindowCount,
 			}
 		}
 		return sessions
@@ -2638,12 +5920,14 @@ func (m *Model) saveSessionCache() {
 		cached[i] = cachedSession{
 			Name:         s.Name,
 			LastActivity: s.LastActivity,
+			WindowCount:  s.WindowCount,
 		}
 	}
 
 	cache := sessionCache{
 		Sessions:     cached,
 		MaxNameWidth: m.maxNameWidth,
+		NameDisplay:  m.nameDisplay(),
 	}
 
 	data, err := json.Marshal(cache)
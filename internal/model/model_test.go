@@ -1,10 +1,28 @@
 package model
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/black-atom-industries/helm/internal/claude"
 	"github.com/black-atom-industries/helm/internal/config"
+	"github.com/black-atom-industries/helm/internal/git"
+	"github.com/black-atom-industries/helm/internal/project"
 	"github.com/black-atom-industries/helm/internal/tmux"
+	"github.com/black-atom-industries/helm/internal/tmux/tmuxtest"
+	"github.com/black-atom-industries/helm/internal/ui"
 )
 
 func TestFuzzyMatch(t *testing.T) {
@@ -183,6 +201,7 @@ func TestSetError(t *testing.T) {
 
 func TestNew(t *testing.T) {
 	cfg := config.DefaultConfig()
+	cfg.CacheDir = t.TempDir()
 	m := New("current-session", cfg)
 
 	if m.currentSession != "current-session" {
@@ -283,6 +302,35 @@ func TestContentWidth(t *testing.T) {
 	}
 }
 
+func TestAppWidthBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    int
+		minWidth int
+		maxWidth int
+		want     int
+	}{
+		{name: "unknown width stays zero", width: 0, maxWidth: 80, want: 0},
+		{name: "no bounds", width: 200, want: 200},
+		{name: "clamped to max", width: 200, maxWidth: 80, want: 80},
+		{name: "narrower than max", width: 60, maxWidth: 80, want: 60},
+		{name: "raised to min", width: 30, minWidth: 40, want: 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.MinWidth = tt.minWidth
+			cfg.MaxWidth = tt.maxWidth
+			m := Model{width: tt.width, config: cfg}
+			got := m.appWidth()
+			if got != tt.want {
+				t.Errorf("appWidth() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestContentHeight(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -404,3 +452,1764 @@ func TestProjectMaxVisibleItems(t *testing.T) {
 		})
 	}
 }
+
+func TestCurrentSessionLabel(t *testing.T) {
+	home := os.Getenv("HOME")
+
+	tests := []struct {
+		name    string
+		session string
+		path    string
+		want    string
+	}{
+		{name: "no current session", session: "", path: "", want: ""},
+		{name: "path not resolved yet", session: "main", path: "", want: "current: main"},
+		{name: "absolute path", session: "main", path: "/tmp/work", want: "current: main (/tmp/work)"},
+		{name: "home is shortened", session: "main", path: home + "/repos/helm", want: "current: main (~/repos/helm)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{currentSession: tt.session, currentPath: tt.path}
+			got := m.currentSessionLabel()
+			if got != tt.want {
+				t.Errorf("currentSessionLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionMaxVisibleItemsCompact(t *testing.T) {
+	items := []Item{{Type: ItemTypeSession, SessionIndex: 0}}
+
+	tests := []struct {
+		name    string
+		compact bool
+		want    int
+	}{
+		{name: "full mode reserves table header", compact: false, want: 18}, // 28 - 10
+		{name: "compact mode drops table header", compact: true, want: 20},  // 28 - 8
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{
+				height:         30,
+				items:          items,
+				sessionsLoaded: true,
+				compact:        tt.compact,
+			}
+			got := m.sessionMaxVisibleItems()
+			if got != tt.want {
+				t.Errorf("sessionMaxVisibleItems() compact=%v = %d, want %d", tt.compact, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreserveSessionState(t *testing.T) {
+	old := []tmux.Session{
+		{Name: "alpha", Expanded: true, Windows: []tmux.Window{{Index: 1, Name: "editor"}}},
+		{Name: "beta"},
+	}
+	fresh := []tmux.Session{
+		{Name: "gamma"},
+		{Name: "alpha"},
+		{Name: "beta"},
+	}
+
+	got := preserveSessionState(old, fresh)
+
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3", len(got))
+	}
+	if got[0].Expanded || len(got[0].Windows) != 0 {
+		t.Errorf("new session gamma should start collapsed without windows")
+	}
+	if !got[1].Expanded || len(got[1].Windows) != 1 {
+		t.Errorf("alpha should keep expansion and windows, got expanded=%v windows=%d", got[1].Expanded, len(got[1].Windows))
+	}
+	if got[2].Expanded {
+		t.Errorf("beta should stay collapsed")
+	}
+}
+
+func TestRestoreCursor(t *testing.T) {
+	m := Model{
+		sessions: []tmux.Session{{Name: "alpha"}, {Name: "beta"}, {Name: "gamma"}},
+	}
+	m.rebuildItems()
+
+	m.restoreCursor("gamma")
+	if m.cursor != 2 {
+		t.Errorf("cursor = %d, want 2", m.cursor)
+	}
+
+	m.restoreCursor("gone")
+	if m.cursor != 2 {
+		t.Errorf("cursor moved for missing target: %d", m.cursor)
+	}
+}
+
+func TestTooSmall(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		want   bool
+	}{
+		{name: "unknown size", width: 0, height: 0, want: false},
+		{name: "usable size", width: 80, height: 24, want: false},
+		{name: "too narrow", width: 20, height: 24, want: true},
+		{name: "too short", width: 80, height: 8, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{width: tt.width, height: tt.height}
+			if got := m.tooSmall(); got != tt.want {
+				t.Errorf("tooSmall() %dx%d = %v, want %v", tt.width, tt.height, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmKillAttachedWarning(t *testing.T) {
+	tests := []struct {
+		name     string
+		attached int
+		want     string
+	}{
+		{name: "detached session", attached: 0, want: `Kill "work"?`},
+		{name: "one client", attached: 1, want: "Session is attached in 1 client — kill anyway?"},
+		{name: "several clients", attached: 3, want: "Session is attached in 3 clients — kill anyway?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{sessions: []tmux.Session{{Name: "work", Attached: tt.attached}}}
+			m.rebuildItems()
+			m.confirmKill()
+			if m.message != tt.want {
+				t.Errorf("message = %q, want %q", m.message, tt.want)
+			}
+			if m.mode != ModeConfirmKill {
+				t.Errorf("mode = %v, want ModeConfirmKill", m.mode)
+			}
+		})
+	}
+}
+
+func TestRebuildItemsMatchesWindowNames(t *testing.T) {
+	m := Model{
+		sessions: []tmux.Session{
+			{Name: "api", Windows: []tmux.Window{{Index: 1, Name: "editor"}, {Index: 2, Name: "logs"}}},
+			{Name: "web", Windows: []tmux.Window{{Index: 1, Name: "editor"}}},
+			{Name: "logs-viewer", Windows: []tmux.Window{{Index: 1, Name: "main"}}},
+		},
+		filter: "logs",
+	}
+	m.rebuildItems()
+
+	want := []Item{
+		{Type: ItemTypeSession, SessionIndex: 0},
+		{Type: ItemTypeWindow, SessionIndex: 0, WindowIndex: 1},
+		{Type: ItemTypeSession, SessionIndex: 2},
+	}
+	if len(m.items) != len(want) {
+		t.Fatalf("items = %+v, want %+v", m.items, want)
+	}
+	for i := range want {
+		if m.items[i] != want[i] {
+			t.Errorf("items[%d] = %+v, want %+v", i, m.items[i], want[i])
+		}
+	}
+}
+
+func TestTildePath(t *testing.T) {
+	t.Setenv("HOME", "/home/bob")
+	tests := map[string]string{
+		"/home/bob":           "~",
+		"/home/bob/repos/api": "~/repos/api",
+		"/home/bobby/repos":   "/home/bobby/repos",
+		"/srv/home/bob":       "/srv/home/bob",
+	}
+	for path, want := range tests {
+		if got := tildePath(path); got != want {
+			t.Errorf("tildePath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestFilterLoadsWindowsInBackground(t *testing.T) {
+	fake := &tmuxtest.Fake{Sessions: []tmux.Session{
+		{Name: "api", Windows: []tmux.Window{{Index: 1, Name: "logs"}}},
+	}}
+	m := Model{client: fake, sessions: []tmux.Session{{Name: "api"}}}
+
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("logs")})
+	if len(m.sessions[0].Windows) != 0 || !m.filterWindowsLoading {
+		t.Fatalf("windows %v, loading %v: want them loaded in the background", m.sessions[0].Windows, m.filterWindowsLoading)
+	}
+	if next := m.loadWindowsForFilterCmd(); next != nil {
+		t.Error("a second key press should not start another load")
+	}
+
+	msg, ok := cmd().(windowsLoadedMsg) // auto_switch is off, so the load is the only command
+	if !ok {
+		t.Fatal("want the window load as the only command")
+	}
+	next, _ := m.Update(msg)
+	m = next.(Model)
+	if m.filterWindowsLoading || len(m.sessions[0].Windows) != 1 || len(m.items) != 2 {
+		t.Errorf("loading %v, windows %v, items %+v: want the window matched", m.filterWindowsLoading, m.sessions[0].Windows, m.items)
+	}
+}
+
+func TestExpandCurrentSingleExpand(t *testing.T) {
+	tests := []struct {
+		name         string
+		singleExpand bool
+		wantFirst    bool
+	}{
+		{name: "single expand collapses others", singleExpand: true, wantFirst: false},
+		{name: "multi expand keeps others", singleExpand: false, wantFirst: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.SingleExpand = tt.singleExpand
+			m := Model{
+				config: cfg,
+				sessions: []tmux.Session{
+					{Name: "a", Expanded: true, Windows: []tmux.Window{{Index: 1, Name: "w"}}},
+					{Name: "b", Windows: []tmux.Window{{Index: 1, Name: "w"}}},
+				},
+			}
+			m.rebuildItems()
+			m.cursor = 2 // session "b" (after a's window)
+			m.expandCurrent()
+
+			if !m.sessions[1].Expanded {
+				t.Error("selected session should be expanded")
+			}
+			if m.sessions[0].Expanded != tt.wantFirst {
+				t.Errorf("first session expanded = %v, want %v", m.sessions[0].Expanded, tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestFirstRunWelcome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	cfg := config.DefaultConfig()
+
+	m := New("current", cfg)
+	if !m.welcome || m.message == "" {
+		t.Fatal("first run without config should show the welcome banner")
+	}
+	if m.welcomeSeen() {
+		t.Fatal("New should not write the welcome marker")
+	}
+	m.markWelcomeSeenCmd()()
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	if m.welcome || m.message != "" {
+		t.Error("any key should dismiss the welcome banner")
+	}
+
+	if again := New("current", cfg); again.welcome {
+		t.Error("welcome banner should only be shown once")
+	}
+}
+
+func TestShowsCurrentRow(t *testing.T) {
+	m := Model{
+		config:      config.Config{ShowCurrent: true},
+		height:      30,
+		currentInfo: &tmux.Session{Name: "current"},
+	}
+	without := Model{height: 30}
+
+	if !m.showsCurrentRow() {
+		t.Error("current row should be shown when loaded")
+	}
+	if got, want := m.sessionMaxVisibleItems(), without.sessionMaxVisibleItems()-1; got != want {
+		t.Errorf("sessionMaxVisibleItems() = %d, want %d (one line for the current row)", got, want)
+	}
+
+	m.filter = "x"
+	if m.showsCurrentRow() {
+		t.Error("current row should be hidden while filtering")
+	}
+}
+
+func TestProjectRootLabel(t *testing.T) {
+	m := Model{config: config.Config{ProjectDirs: []string{"/home/u/repos", "/home/u/work", "/home/u/work/clients"}}}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/home/u/repos/owner/app", want: "repos"},
+		{path: "/home/u/work/owner/app", want: "work"},
+		{path: "/home/u/work/clients/acme/app", want: "clients"},
+		{path: "/home/u/workspace/app", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := m.projectRootLabel(tt.path); got != tt.want {
+			t.Errorf("projectRootLabel(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestNewSessionName(t *testing.T) {
+	now := time.Date(2026, 3, 14, 9, 5, 0, 0, time.UTC)
+	m := Model{
+		currentSession: "scratch-1",
+		sessions:       []tmux.Session{{Name: "scratch-2"}, {Name: "scratch-4"}},
+	}
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{template: "", want: ""},
+		{template: "scratch-{date}", want: "scratch-2026-03-14"},
+		{template: "tmp {time}", want: "tmp-0905"},
+		{template: "scratch-{n}", want: "scratch-3"},
+		{template: "notes-{n}", want: "notes-1"},
+	}
+
+	for _, tt := range tests {
+		m.config.NewSessionTemplate = tt.template
+		if got := m.newSessionName(now); got != tt.want {
+			t.Errorf("newSessionName() with %q = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestSplitCreateInput(t *testing.T) {
+	tests := []struct {
+		input, name, command string
+	}{
+		{input: "notes", name: "notes"},
+		{input: " logs -- tail -f app.log ", name: "logs", command: "tail -f app.log"},
+		{input: "build -- make -- -j4", name: "build", command: "make -- -j4"},
+		{input: "a--b", name: "a--b"},
+		{
+			input:   strings.Repeat("n", 60) + " -- tail -f /var/log/nginx/access.log /var/log/nginx/error.log",
+			name:    strings.Repeat("n", sessionNameLimit),
+			command: "tail -f /var/log/nginx/access.log /var/log/nginx/error.log",
+		},
+	}
+
+	for _, tt := range tests {
+		name, command := splitCreateInput(tt.input)
+		if name != tt.name || command != tt.command {
+			t.Errorf("splitCreateInput(%q) = %q, %q, want %q, %q", tt.input, name, command, tt.name, tt.command)
+		}
+	}
+}
+
+func TestDisplayPath(t *testing.T) {
+	cfg := config.Config{ProjectDirs: []string{"/home/u/repos", "/home/u/work/clients"}, ProjectDepth: 2}
+
+	tests := []struct {
+		mode string
+		path string
+		want string
+	}{
+		{mode: "", path: "/home/u/repos/owner/app", want: "owner/app"},
+		{mode: config.PickerDisplayDepth, path: "/home/u/work/clients/acme/team/app", want: "team/app"},
+		{mode: config.PickerDisplayRelative, path: "/home/u/work/clients/acme/team/app", want: "acme/team/app"},
+		{mode: config.PickerDisplayRelative, path: "/home/u/repos/app", want: "app"},
+		{mode: config.PickerDisplayRelative, path: "/srv/other/owner/app", want: "owner/app"},
+		{mode: config.PickerDisplayFull, path: "/srv/other/owner/app", want: "/srv/other/owner/app"},
+	}
+
+	for _, tt := range tests {
+		cfg.PickerDisplay = tt.mode
+		if got := displayPath(tt.path, cfg); got != tt.want {
+			t.Errorf("displayPath(%q) with %q = %q, want %q", tt.path, tt.mode, got, tt.want)
+		}
+	}
+
+	// The picker filter matches the displayed path, not just the basename
+	cfg.PickerDisplay = config.PickerDisplayRelative
+	list := newProjectList(cfg)
+	list.SetItems([]string{"/home/u/work/clients/acme/team/app", "/home/u/repos/owner/app"})
+	list.SetFilter("acme")
+	if got := list.Filtered(); len(got) != 1 || got[0] != "/home/u/work/clients/acme/team/app" {
+		t.Errorf("filter \"acme\" = %v, want only the acme path", got)
+	}
+}
+
+func TestCloneFromURLInvalid(t *testing.T) {
+	m := Model{cloneBasePath: "/tmp/repos", cloneURLMode: true}
+	_, cmd := m.cloneFromURL("not-a-url")
+	if cmd != nil {
+		t.Error("invalid URL should not start a clone")
+	}
+	if m.cloneError == "" {
+		t.Error("invalid URL should set cloneError")
+	}
+	if m.cloneCloning {
+		t.Error("invalid URL should not enter cloning state")
+	}
+}
+
+func TestCloneURLModeToggle(t *testing.T) {
+	m := Model{
+		mode:          ModeCloneRepo,
+		cloneBasePath: "/tmp/repos",
+		cloneList:     ui.NewScrollList(func(repo, filter string) bool { return strings.Contains(repo, filter) }),
+	}
+	m.cloneList.SetItems([]string{"me/dots"})
+	m.cloneList.SetFilter("do")
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyTab})
+	if !m.cloneURLMode || m.cloneList.Filter() != "" {
+		t.Fatalf("urlMode %v, filter %q: want URL input with the filter cleared", m.cloneURLMode, m.cloneList.Filter())
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("git@github.com:other/tool.git")})
+	if !strings.Contains(m.View(), filepath.Join("/tmp/repos", "other/tool")) {
+		t.Error("view should preview the clone destination")
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyTab})
+	if m.cloneURLMode || m.cloneList.Filter() != "" {
+		t.Errorf("urlMode %v, filter %q: want the repo list back", m.cloneURLMode, m.cloneList.Filter())
+	}
+
+	// Without gh the URL prompt is all there is
+	m.cloneNoGh, m.cloneURLMode = true, true
+	m.handleKey(tea.KeyMsg{Type: tea.KeyTab})
+	if !m.cloneURLMode {
+		t.Error("Tab should not leave URL input when gh is missing")
+	}
+}
+
+func TestSessionPathCache(t *testing.T) {
+	m := Model{
+		config:   config.Config{GitStatusEnabled: true},
+		sessions: []tmux.Session{{Name: "api"}},
+	}
+	m.sessionPaths = map[string]string{"gone": "/tmp"}
+	if m.resolveSessionPathsCmd() == nil {
+		t.Fatal("resolveSessionPathsCmd() = nil, want one command per session")
+	}
+	if _, ok := m.sessionPaths["gone"]; ok {
+		t.Error("paths of sessions that are gone should be dropped")
+	}
+	if git.Available() && !m.gitStatusPending["api"] {
+		t.Error("git statuses should be pending from the start of the load")
+	}
+	if _, ok := m.sessionPath("api"); ok {
+		t.Error("sessionPath() should miss until the path has arrived")
+	}
+
+	dir := t.TempDir()
+	next, cmd := m.Update(sessionPathMsg{name: "api", path: dir})
+	m = next.(Model)
+	if path, ok := m.sessionPath("api"); !ok || path != dir {
+		t.Errorf("sessionPath(api) = %q, %v, want cached %q", path, ok, dir)
+	}
+	if git.Available() && cmd == nil {
+		t.Error("a resolved path should start that session's git status fetch")
+	}
+}
+
+func TestMessageLog(t *testing.T) {
+	m := Model{}
+	m.setMessage("first")
+	m.setError("second")
+
+	if len(m.messages) != 2 {
+		t.Fatalf("len(messages) = %d, want 2", len(m.messages))
+	}
+	if m.messages[0].Text != "second" || !m.messages[0].IsError {
+		t.Errorf("newest entry = %+v, want error %q", m.messages[0], "second")
+	}
+
+	for i := 0; i < maxLogEntries+10; i++ {
+		m.setMessage("msg %d", i)
+	}
+	if len(m.messages) != maxLogEntries {
+		t.Errorf("len(messages) = %d, want cap %d", len(m.messages), maxLogEntries)
+	}
+}
+
+func TestAutoQuit(t *testing.T) {
+	m := Model{config: config.Config{AutoQuitAfter: "1m"}, lastKeyAt: time.Now().Add(-2 * time.Minute)}
+
+	_, cmd := m.Update(idleTickMsg{})
+	if cmd == nil {
+		t.Fatal("idle past auto_quit_after should quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("idle past auto_quit_after should return tea.Quit")
+	}
+
+	m.cloneCloning = true
+	if _, cmd := m.Update(idleTickMsg{}); cmd == nil {
+		t.Fatal("busy model should re-arm the idle tick")
+	}
+
+	m.cloneCloning = false
+	m.lastKeyAt = time.Now()
+	_, cmd = m.Update(idleTickMsg{})
+	if cmd == nil {
+		t.Fatal("recent key press should re-arm the idle tick")
+	}
+}
+
+func TestReadOnlyBlocksKill(t *testing.T) {
+	m := Model{
+		readOnly: true,
+		sessions: []tmux.Session{{Name: "work"}},
+		items:    []Item{{Type: ItemTypeSession, SessionIndex: 0}},
+	}
+
+	_, cmd := m.confirmKill()
+	if m.mode == ModeConfirmKill || m.killTarget != "" {
+		t.Error("read-only model should not enter kill confirmation")
+	}
+	if m.message == "" || cmd == nil {
+		t.Error("read-only model should explain why the kill was blocked, then clear the message")
+	}
+}
+
+func TestSelectCurrentSessionQuits(t *testing.T) {
+	m := Model{
+		currentSession: "work",
+		config:         config.Config{IncludeCurrent: true},
+		sessions:       []tmux.Session{{Name: "work"}},
+		items:          []Item{{Type: ItemTypeSession, SessionIndex: 0}},
+	}
+
+	_, cmd := m.selectCurrent()
+	if cmd == nil {
+		t.Fatal("selecting the current session should quit")
+	}
+	if m.message != "" {
+		t.Errorf("unexpected message %q: no switch should be attempted", m.message)
+	}
+}
+
+func TestJumpToLetter(t *testing.T) {
+	m := Model{
+		height:   30,
+		sessions: []tmux.Session{{Name: "api"}, {Name: "blog"}, {Name: "Admin"}},
+		items: []Item{
+			{Type: ItemTypeSession, SessionIndex: 0},
+			{Type: ItemTypeSession, SessionIndex: 1},
+			{Type: ItemTypeSession, SessionIndex: 2},
+		},
+	}
+
+	// Repeated presses cycle through matches, case-insensitively
+	for _, want := range []int{2, 0, 2} {
+		m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}, Alt: true})
+		if m.cursor != want {
+			t.Errorf("cursor = %d, want %d", m.cursor, want)
+		}
+	}
+	if m.filter != "" {
+		t.Errorf("alt+letter should not feed the filter, got %q", m.filter)
+	}
+
+	m.jumpToLetter('z')
+	if m.cursor != 2 {
+		t.Errorf("no match should keep the cursor, got %d", m.cursor)
+	}
+}
+
+func TestRecentProjects(t *testing.T) {
+	root := t.TempDir()
+	dirs := []string{"owner/api", "owner/blog", "owner/docs"}
+	for _, d := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := Model{
+		config:   config.Config{RecentProjects: 1, ProjectDepth: 2, CacheDir: t.TempDir()},
+		sessions: []tmux.Session{{Name: "owner-api"}},
+	}
+	for _, d := range dirs {
+		m.recordRecentProject(filepath.Join(root, d))
+	}
+	m.recordRecentProject(filepath.Join(root, "owner/api")) // moves to the front
+
+	if got := m.loadRecentHistory(); len(got) != 3 || got[0] != filepath.Join(root, "owner/api") {
+		t.Fatalf("history = %v, want 3 paths with owner/api first", got)
+	}
+
+	// owner/api has a session; only one row is shown (recent_projects: 1)
+	m.recentProjects = m.recentProjectsWithoutSession()
+	m.rebuildItems()
+	if len(m.recentRows) != 1 || m.recentRows[0] != filepath.Join(root, "owner/docs") {
+		t.Errorf("recentRows = %v, want [owner/docs]", m.recentRows)
+	}
+	if m.items[0].Type != ItemTypeSession || m.items[1].Type != ItemTypeRecent || m.cursor != 0 {
+		t.Errorf("recent rows should follow the sessions with the cursor on the first session, got %+v (cursor %d)", m.items, m.cursor)
+	}
+
+	// The directories are checked once per load, not on every rebuild
+	if err := os.RemoveAll(filepath.Join(root, "owner/docs")); err != nil {
+		t.Fatal(err)
+	}
+	m.rebuildItems()
+	if len(m.recentRows) != 1 {
+		t.Errorf("recentRows = %v, want the cached row until the next load", m.recentRows)
+	}
+
+	m.filter = "api"
+	m.rebuildItems()
+	if len(m.recentRows) != 0 {
+		t.Error("recent rows should be hidden while filtering")
+	}
+}
+
+func TestRebuildItemsMatchesPaths(t *testing.T) {
+	m := Model{
+		sessions: []tmux.Session{{Name: "api"}, {Name: "blog"}, {Name: "notes"}},
+		sessionPaths: map[string]string{
+			"api":  "/home/u/work/acme/api",
+			"blog": "/home/u/repos/me/blog",
+		},
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{filter: "acme", want: []string{"api"}},    // path fragment
+		{filter: "bl", want: []string{"blog"}},     // too short for paths: name only
+		{filter: "@repos", want: []string{"blog"}}, // scoped to paths
+		{filter: "@notes", want: nil},              // unresolved path never matches @
+		{filter: "notes", want: []string{"notes"}}, // name still matches without a path
+		{filter: "@", want: []string{"api", "blog", "notes"}},
+	}
+
+	for _, tt := range tests {
+		m.filter = tt.filter
+		m.rebuildItems()
+		var got []string
+		for _, item := range m.items {
+			got = append(got, m.sessions[item.SessionIndex].Name)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("filter %q: got %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestUniqueSessionName(t *testing.T) {
+	taken := map[string]bool{"api-2": true, "api-3": true}
+	exists := func(name string) bool { return taken[name] }
+
+	if got := uniqueSessionName("api", exists); got != "api-4" {
+		t.Errorf("uniqueSessionName() = %q, want %q", got, "api-4")
+	}
+	if got := uniqueSessionName("blog", exists); got != "blog-2" {
+		t.Errorf("uniqueSessionName() = %q, want %q", got, "blog-2")
+	}
+}
+
+func TestEnvVersionsCmd(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".nvmrc"), []byte("v20.11.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := Model{
+		envReader:    project.NewEnvReader(nil),
+		sessions:     []tmux.Session{{Name: "api"}, {Name: "blog"}},
+		sessionPaths: map[string]string{"api": dir},
+	}
+	m.rebuildItems()
+
+	cmd := m.envVersionsCmd()
+	if cmd == nil {
+		t.Fatal("expected a command for the visible api row")
+	}
+	m.Update(cmd())
+	if got := m.envVersions["api"]; got != "node@20.11.0" {
+		t.Errorf("envVersions[api] = %q, want %q", got, "node@20.11.0")
+	}
+	if _, ok := m.envVersions["blog"]; ok {
+		t.Error("blog has no resolved path and should not be loaded")
+	}
+
+	// Rows are only read once
+	if m.envVersionsCmd() != nil {
+		t.Error("expected no command once visible rows are loaded")
+	}
+}
+
+func TestWarnOnQuit(t *testing.T) {
+	m := Model{
+		config:      config.Config{WarnOnQuit: true},
+		sessions:    []tmux.Session{{Name: "api", Watched: true}, {Name: "blog"}, {Name: "docs"}},
+		gitStatuses: map[string]git.Status{"blog": {IsRepo: true, Dirty: 2}, "docs": {IsRepo: true, Additions: 1}},
+	}
+
+	if _, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlC}); cmd != nil || m.mode != ModeConfirmQuit {
+		t.Fatalf("expected the quit prompt, got mode %v", m.mode)
+	}
+	if want := "You have 1 session with uncommitted changes and 1 session running watched commands — quit anyway?"; m.message != want {
+		t.Errorf("message = %q, want %q", m.message, want)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeNormal {
+		t.Errorf("Esc should cancel, got mode %v", m.mode)
+	}
+
+	m.gitStatuses, m.sessions[0].Watched = nil, false
+	if _, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlC}); cmd == nil {
+		t.Error("nothing to warn about should quit right away")
+	}
+}
+
+func TestRowSpacing(t *testing.T) {
+	m := Model{
+		config: config.Config{RowSpacing: 1},
+		height: 20,
+		sessions: []tmux.Session{
+			{Name: "a", Expanded: true, Windows: []tmux.Window{{Index: 1}, {Index: 2}}},
+			{Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"}, {Name: "f"}, {Name: "g"},
+		},
+	}
+	m.rebuildItems()
+	lines := m.sessionMaxVisibleItems()
+
+	// Every shown item plus the blank lines above sessions must fit the list height
+	used := 0
+	end := m.sessionVisibleEnd(0)
+	for i := 0; i < end; i++ {
+		used += 1 + m.spacingBefore(i, 0)
+	}
+	if used > lines {
+		t.Errorf("visible items use %d lines, only %d available", used, lines)
+	}
+	if end >= len(m.items) {
+		t.Fatalf("expected %d items not to fit in %d lines", len(m.items), lines)
+	}
+
+	// Moving to the last item scrolls just far enough to show it
+	m.cursor = len(m.items) - 1
+	m.updateScrollOffset()
+	if got := m.sessionVisibleEnd(m.scrollOffset); got != len(m.items) {
+		t.Errorf("last item not visible: shown items end at %d of %d", got, len(m.items))
+	}
+	if m.scrollOffset > 0 && m.sessionVisibleEnd(m.scrollOffset-1) == len(m.items) {
+		t.Errorf("scrolled further than needed (offset %d)", m.scrollOffset)
+	}
+}
+
+func TestSessionGroups(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"work", "work-2", "gone"} {
+		if err := claude.WriteStatus(name, dir, "working", claude.FormatColon); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := New("", config.Config{CacheDir: dir, ClaudeStatusEnabled: true})
+	m.width, m.height = 80, 30
+	m.sessions = tmux.CollapseGroups([]tmux.Session{{Name: "work", Group: "work"}, {Name: "work-2", Group: "work"}, {Name: "notes"}})
+	m.sessionsLoaded = true
+	m.rebuildItems()
+
+	// work-2 is folded into work's row but still alive
+	m.cleanupClaudeStatusCmd()()
+	for name, want := range map[string]bool{"work": true, "work-2": true, "gone": false} {
+		if _, err := os.Stat(filepath.Join(dir, name+".status")); (err == nil) != want {
+			t.Errorf("%s.status kept = %v, want %v", name, err == nil, want)
+		}
+	}
+
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, ui.GroupHeaderPrefix+" work: work, work-2") {
+		t.Errorf("view is missing the group header:\n%s", view)
+	}
+	if got := lipgloss.Height(view); got != m.height {
+		t.Errorf("view is %d lines, want %d with the header counted", got, m.height)
+	}
+}
+
+func TestCarryFilter(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"acme/api", "acme/blog"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := config.Config{ProjectDirs: []string{root}, ProjectDepth: 2}
+
+	for _, carry := range []bool{false, true} {
+		cfg.CarryFilter = carry
+		m := New("", cfg)
+		m.filter = "@blo"
+		m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlP})
+
+		wantFilter, wantLen := "", 2
+		if carry {
+			wantFilter, wantLen = "blo", 1
+		}
+		if m.mode != ModePickDirectory || m.filter != "" {
+			t.Fatalf("carry=%v: mode %v, filter %q", carry, m.mode, m.filter)
+		}
+		if got := m.projectList.Filter(); got != wantFilter || m.projectList.Len() != wantLen {
+			t.Errorf("carry=%v: picker filter %q with %d entries, want %q with %d", carry, got, m.projectList.Len(), wantFilter, wantLen)
+		}
+	}
+}
+
+func TestScanHiddenDirectories(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"acme/api", "acme/.git", ".work/notes", ".cache/x"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		cfg  config.Config
+		want []string
+	}{
+		{name: "default", want: []string{"acme/api"}},
+		{name: "allowlist", cfg: config.Config{HiddenDirs: []string{".work"}}, want: []string{".work/notes", "acme/api"}},
+		{name: "all", cfg: config.Config{IncludeHidden: true}, want: []string{".cache/x", ".work/notes", "acme/api"}},
+	}
+
+	for _, tt := range tests {
+		tt.cfg.ProjectDirs, tt.cfg.ProjectDepth = []string{root}, 2
+		m := Model{config: tt.cfg}
+		var got []string
+		for _, dir := range m.scanProjectDirectories() {
+			rel, _ := filepath.Rel(root, dir)
+			got = append(got, rel)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: scanned %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSelectionDetail(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	m := Model{
+		sessions: []tmux.Session{
+			{Name: "api", WindowCount: 3, Expanded: true, Windows: []tmux.Window{{Index: 1, Name: "edit"}}},
+			{Name: "blog"},
+		},
+		sessionPaths: map[string]string{"api": filepath.Join(home, "repos", "api")},
+		itemPaths:    map[string]string{"api:1": "/srv/api"},
+	}
+	m.rebuildItems()
+
+	tests := []struct {
+		cursor int
+		want   string
+	}{
+		{cursor: 0, want: "~/repos/api · 3 windows"},
+		{cursor: 1, want: "/srv/api"},
+		{cursor: 2, want: ""},
+	}
+	for _, tt := range tests {
+		m.cursor = tt.cursor
+		if got := m.selectionDetail(); got != tt.want {
+			t.Errorf("selectionDetail() at %d = %q, want %q", tt.cursor, got, tt.want)
+		}
+	}
+
+	// Windows are resolved once, when the cursor lands on them
+	m.cursor = 1
+	if m.selectedPathCmd() != nil {
+		t.Error("resolved window should not be fetched again")
+	}
+}
+
+func TestExpandCollapseAll(t *testing.T) {
+	m := Model{sessions: []tmux.Session{
+		{Name: "api", Windows: []tmux.Window{{Index: 1, Name: "edit"}}},
+		{Name: "blog"},
+		{Name: "docs"},
+	}}
+	m.rebuildItems()
+	m.cursor = 1 // blog
+
+	m.applyExpandAll(map[string][]tmux.Window{"blog": {{Index: 1, Name: "draft"}, {Index: 2, Name: "serve"}}})
+	if !m.sessions[0].Expanded || !m.sessions[1].Expanded || m.sessions[2].Expanded {
+		t.Errorf("expanded = %v %v %v, want api and blog (docs has no windows)",
+			m.sessions[0].Expanded, m.sessions[1].Expanded, m.sessions[2].Expanded)
+	}
+	if len(m.items) != 6 {
+		t.Fatalf("items = %d, want 3 sessions + 3 windows", len(m.items))
+	}
+	if got := m.getTargetName(m.items[m.cursor]); got != "blog" {
+		t.Errorf("cursor on %q after expand-all, want blog", got)
+	}
+	if m.message != "Expanded 2 sessions" {
+		t.Errorf("message = %q", m.message)
+	}
+
+	m.cursor = 4 // blog:2
+	m.collapseAll()
+	if len(m.items) != 3 {
+		t.Fatalf("items = %d, want 3 sessions", len(m.items))
+	}
+	if got := m.getTargetName(m.items[m.cursor]); got != "blog" {
+		t.Errorf("cursor on %q after collapse-all, want the window's session", got)
+	}
+}
+
+func TestHandleJumpWindowPosition(t *testing.T) {
+	m := Model{
+		printSelection: true,
+		sessions: []tmux.Session{{
+			Name:     "api",
+			Expanded: true,
+			Windows:  []tmux.Window{{Index: 1, Name: "edit"}, {Index: 3, Name: "test"}, {Index: 7, Name: "logs"}},
+		}},
+	}
+	m.rebuildItems()
+
+	if _, cmd := m.handleJump(2); cmd == nil {
+		t.Fatal("expected quit after jumping to a window")
+	}
+	if m.selection != "api:3" {
+		t.Errorf("selection = %q, want %q (second window)", m.selection, "api:3")
+	}
+}
+
+func TestJumpPrefix(t *testing.T) {
+	sessions := []tmux.Session{{Name: "api"}, {Name: "2024"}, {Name: "web"}}
+	digit := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")}
+
+	m := Model{printSelection: true, sessions: sessions, config: config.Config{JumpPrefix: config.JumpPrefixAlt}}
+	m.rebuildItems()
+	m.handleKey(digit)
+	if m.filter != "2" || m.selection != "" {
+		t.Fatalf("filter %q, selection %q: a bare digit should filter with jump_prefix", m.filter, m.selection)
+	}
+	if _, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2"), Alt: true}); cmd == nil || m.selection != "web" {
+		t.Errorf("selection = %q, want M-2 to jump to web", m.selection)
+	}
+
+	m = Model{printSelection: true, sessions: sessions, config: config.Config{JumpPrefix: "g"}}
+	m.rebuildItems()
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if m.filter != "gi" {
+		t.Fatalf("filter = %q, want the prefix kept when no digit follows", m.filter)
+	}
+	m.filter = ""
+	m.rebuildItems()
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	m.handleKey(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if m.filter != "" || len(m.selected) != 1 {
+		t.Fatalf("filter %q, marked %v: a bound key after the prefix should run without filtering", m.filter, m.selected)
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if _, cmd := m.handleKey(digit); cmd == nil || m.selection != "web" {
+		t.Errorf("selection = %q, want g 2 to jump to web", m.selection)
+	}
+}
+
+func TestNameWidthCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	m := Model{config: config.Config{CacheDir: cacheDir}, sessions: []tmux.Session{{Name: "ĉambro"}}}
+	m.calculateColumnWidths()
+	if m.maxNameWidth != 6 {
+		t.Errorf("maxNameWidth = %d, want 6 cells for ĉambro", m.maxNameWidth)
+	}
+
+	m.config.NameDisplay = config.NameDisplayPath
+	m.maxNameWidth = 40 // A long path
+	m.saveSessionCache()
+
+	for _, tt := range []struct {
+		display string
+		want    int
+	}{
+		{config.NameDisplayPath, 40},
+		{config.NameDisplayTmux, 0},
+		{"", 0},
+	} {
+		fresh := Model{config: config.Config{CacheDir: cacheDir, NameDisplay: tt.display}}
+		if fresh.loadSessionCache() == nil || fresh.maxNameWidth != tt.want {
+			t.Errorf("name_display %q: cached maxNameWidth = %d, want %d", tt.display, fresh.maxNameWidth, tt.want)
+		}
+	}
+}
+
+func TestServerCachePerSocket(t *testing.T) {
+	cacheDir := t.TempDir()
+	work := Model{config: config.Config{CacheDir: cacheDir, Socket: "/tmp/work.sock"}, sessions: []tmux.Session{{Name: "api"}}}
+	work.saveSessionCache()
+	work.starred = map[string]bool{"api": true}
+	work.saveStarred()
+
+	// The default server and another socket see neither the list nor the stars
+	for _, socket := range []string{"", "personal"} {
+		other := Model{config: config.Config{CacheDir: cacheDir, Socket: socket}}
+		if other.loadSessionCache() != nil || len(other.loadStarred()) != 0 {
+			t.Errorf("socket %q reads the work server's cache", socket)
+		}
+	}
+
+	again := Model{config: work.config}
+	if cached := again.loadSessionCache(); len(cached) != 1 || !again.loadStarred()["api"] {
+		t.Errorf("work server cache = %v, want its own session and star back", cached)
+	}
+}
+
+func TestNameDisplayPath(t *testing.T) {
+	session := tmux.Session{Name: "nikbrunner-nbr-haus"}
+	m := Model{
+		config:   config.Config{NameDisplay: config.NameDisplayPath, ProjectDepth: 2},
+		sessions: []tmux.Session{session, {Name: "scratch"}},
+	}
+	if got := m.sessionDisplayName(session); got != session.Name {
+		t.Errorf("before paths resolve: %q, want the tmux name", got)
+	}
+
+	next, _ := m.Update(sessionPathMsg{name: session.Name, path: "/home/me/repos/nikbrunner/nbr.haus"})
+	m = next.(Model)
+	if got := m.sessionDisplayName(session); got != "nikbrunner/nbr.haus" {
+		t.Errorf("sessionDisplayName() = %q, want the directory", got)
+	}
+	if m.maxNameWidth != len("nikbrunner/nbr.haus") {
+		t.Errorf("maxNameWidth = %d, want it widened for the path", m.maxNameWidth)
+	}
+
+	m.config.NameDisplay = config.NameDisplayTmux
+	if got := m.sessionDisplayName(session); got != session.Name {
+		t.Errorf("name_display tmux: %q, want the tmux name", got)
+	}
+}
+
+func TestLaunchPopupMissingBinary(t *testing.T) {
+	m := Model{}
+	m.launchPopupInDir("helm-test-no-such-fm --cwd", "/tmp")
+	if !m.messageIsError || !strings.Contains(m.message, "helm-test-no-such-fm is not installed") {
+		t.Errorf("message = %q (error %v), want a missing binary error", m.message, m.messageIsError)
+	}
+}
+
+func TestPopupScript(t *testing.T) {
+	m := Model{config: config.Config{LazygitPopup: config.PopupConfig{Width: "90%", Height: "90%"}}, width: 60, height: 20}
+	got := m.popupScript(`gh pr list -R o/r; printf '\nPress enter'`, "/tmp/it's $HOME")
+
+	want := `sleep 0.1 && tmux display-popup -w90% -h90% -d '/tmp/it'\''s $HOME' -E 'gh pr list -R o/r; printf '\''\nPress enter'\'''; `
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("popupScript() = %s\nwant prefix %s", got, want)
+	}
+}
+
+func TestLaunchPopupOnServerPath(t *testing.T) {
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "helm-test-tool"), nil, 0755); err != nil {
+		t.Fatal(err)
+	}
+	fake := &tmuxtest.Fake{Env: map[string]string{"PATH": bin}}
+	m := Model{client: fake}
+
+	if _, cmd := m.launchPopupInDir("helm-test-tool --flag", "/tmp"); cmd == nil || len(fake.Shells) != 1 {
+		t.Fatalf("shells %v: want the popup started from the server's PATH", fake.Shells)
+	}
+	m.launchPopupInDir("helm-missing-tool", "/tmp")
+	if len(fake.Shells) != 1 || !strings.Contains(m.message, "not installed") {
+		t.Errorf("shells %v, message %q: want a missing binary reported", fake.Shells, m.message)
+	}
+}
+
+func TestDisablePopups(t *testing.T) {
+	m := Model{
+		config:   config.Config{DisablePopups: true},
+		sessions: []tmux.Session{{Name: "api"}},
+	}
+	m.rebuildItems()
+
+	for _, msg := range []tea.KeyMsg{{Type: tea.KeyCtrlG}, {Type: tea.KeyCtrlW}, {Type: tea.KeyCtrlE}, {Type: tea.KeyCtrlU}} {
+		m.message = ""
+		if _, cmd := m.handleKey(msg); cmd == nil || !strings.Contains(m.message, "disabled") {
+			t.Errorf("%s: message %q, want popups reported as disabled", msg, m.message)
+		}
+	}
+	if m.mode != ModeNormal {
+		t.Errorf("mode = %v, want normal", m.mode)
+	}
+}
+
+func TestGoToSessionNumber(t *testing.T) {
+	var sessions []tmux.Session
+	for i := range 15 {
+		sessions = append(sessions, tmux.Session{Name: fmt.Sprintf("s%d", i)})
+	}
+	m := Model{printSelection: true, sessions: sessions, filter: "s1"}
+	m.rebuildItems()
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlF})
+	if m.mode != ModeGoTo || m.filter != "" {
+		t.Fatalf("mode %v, filter %q: want go-to prompt with the filter cleared", m.mode, m.filter)
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1x2")})
+	if m.gotoInput != "12" || m.items[m.cursor].SessionIndex != 12 {
+		t.Fatalf("input %q, cursor on session %d: want 12", m.gotoInput, m.items[m.cursor].SessionIndex)
+	}
+
+	if _, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Fatal("expected quit after switching")
+	}
+	if m.selection != "s12" || m.mode != ModeNormal {
+		t.Errorf("selection = %q, mode %v, want s12 in normal mode", m.selection, m.mode)
+	}
+}
+
+func TestCreateSessionWithFakeTmux(t *testing.T) {
+	fake := &tmuxtest.Fake{}
+	m := Model{client: fake, config: config.Config{DefaultSessionDir: "/tmp"}}
+
+	if _, cmd := m.createSession("logs view", "tail -f app.log"); cmd == nil {
+		t.Fatalf("expected quit after creating, got message %q", m.message)
+	}
+	if !fake.SessionExists("logs-view") || fake.Switched != "logs-view" {
+		t.Errorf("sessions %v, switched to %q: want logs-view created and switched to", fake.Sessions, fake.Switched)
+	}
+	if len(fake.Ran) != 1 || fake.Ran[0] != "logs-view: tail -f app.log" {
+		t.Errorf("ran %v, want the command in logs-view", fake.Ran)
+	}
+}
+
+func TestCreateNameExists(t *testing.T) {
+	fake := &tmuxtest.Fake{}
+	_ = fake.CreateSession("my-notes", "/tmp", "")
+	m := New("", config.Config{})
+	m.client = fake
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlN})
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("my notes")})
+	if !m.createExists || m.stateText() != "Exists: Enter switches to it" {
+		t.Fatalf("createExists = %v, state %q: want the collision flagged", m.createExists, m.stateText())
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	if m.createExists {
+		t.Fatal("my notes2 doesn't exist")
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyBackspace})
+
+	if _, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil || fake.Switched != "my-notes" || len(fake.Sessions) != 1 {
+		t.Errorf("switched to %q with %d sessions: want a switch to my-notes, nothing created", fake.Switched, len(fake.Sessions))
+	}
+}
+
+func TestCreateHistory(t *testing.T) {
+	fake := &tmuxtest.Fake{}
+	m := New("", config.Config{CacheDir: t.TempDir()})
+	m.client = fake
+	up, down := tea.KeyMsg{Type: tea.KeyUp}, tea.KeyMsg{Type: tea.KeyDown}
+	logs := "logs -- tail -f /var/log/nginx/access.log /var/log/nginx/error.log" // Longer than a session name
+
+	// Nothing recorded yet: up keeps the pre-filled name
+	m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlN})
+	prefilled := m.input.Value()
+	m.handleKey(up)
+	if m.input.Value() != prefilled {
+		t.Fatalf("input = %q, want %q with an empty history", m.input.Value(), prefilled)
+	}
+
+	for _, input := range []string{logs, "notes", logs} {
+		m.input.SetValue(input)
+		m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	if got := m.loadCreateHistory(); len(got) != 2 || got[0] != logs {
+		t.Fatalf("history = %q, want logs first and no duplicates", got)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlN})
+	m.input.SetValue("draft")
+	for _, want := range []string{logs, "notes", "notes"} {
+		m.handleKey(up)
+		if m.input.Value() != want {
+			t.Errorf("up: input = %q, want %q", m.input.Value(), want)
+		}
+	}
+	m.handleKey(down)
+	if !m.createExists {
+		t.Error("logs exists; browsing should re-check the name")
+	}
+	m.handleKey(down)
+	if m.input.Value() != "draft" || m.createExists {
+		t.Errorf("input = %q (exists %v), want the typed draft restored", m.input.Value(), m.createExists)
+	}
+}
+
+func TestTogglePopups(t *testing.T) {
+	fake := &tmuxtest.Fake{}
+	for _, name := range []string{"api", tmux.PopupPrefix + "scratch"} {
+		_ = fake.CreateSession(name, "/tmp", "")
+	}
+	m := Model{client: fake}
+
+	for _, want := range []int{2, 1} {
+		if _, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlV}); cmd == nil {
+			t.Fatal("expected a reload")
+		}
+		if got := len(m.loadSessions().(sessionsMsg).sessions); got != want {
+			t.Errorf("showPopups=%v: loaded %d sessions, want %d", m.showPopups, got, want)
+		}
+	}
+}
+
+func TestKillCurrentWithFakeTmux(t *testing.T) {
+	fake := &tmuxtest.Fake{}
+	for _, name := range []string{"api", "blog"} {
+		_ = fake.CreateSession(name, "/tmp", "")
+	}
+	fake.Sessions[0].Windows = append(fake.Sessions[0].Windows, tmux.Window{Index: 3, Name: "logs"})
+
+	m := Model{client: fake}
+	load := func() {
+		m.sessions = m.loadSessions().(sessionsMsg).sessions
+		m.rebuildItems()
+	}
+	load()
+	if len(m.sessions) != 2 || m.sessions[0].WindowCount != 2 {
+		t.Fatalf("loaded %+v, want api (2 windows) and blog", m.sessions)
+	}
+
+	// Kill api's second window, then the blog session
+	m.expandCurrent()
+	m.cursor = 2
+	m.killCurrent()
+	if windows, _ := fake.ListWindows("api"); len(windows) != 1 || m.message != "Killed window 3" {
+		t.Errorf("api windows %v, message %q: want window 3 killed", windows, m.message)
+	}
+
+	load()
+	m.restoreCursor("blog")
+	m.killCurrent()
+	if fake.SessionExists("blog") || !fake.SessionExists("api") {
+		t.Errorf("sessions %v, want only api left", fake.Sessions)
+	}
+}
+
+func TestAutoSwitchOnUnique(t *testing.T) {
+	m := Model{
+		config:         config.Config{AutoSwitchOnUnique: true},
+		printSelection: true,
+		sessions:       []tmux.Session{{Name: "api"}, {Name: "apps"}, {Name: "blog"}},
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ap")})
+	if _, cmd := m.Update(autoSwitchMsg{filter: "ap"}); cmd != nil || m.selection != "" {
+		t.Fatal("two matching sessions should not auto-switch")
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+
+	// A check scheduled for an older filter is stale: typing continued
+	if _, cmd := m.Update(autoSwitchMsg{filter: "ap"}); cmd != nil {
+		t.Error("stale check should not switch")
+	}
+
+	next, cmd := m.Update(autoSwitchMsg{filter: "app"})
+	if cmd == nil {
+		t.Fatal("expected quit after auto-switch")
+	}
+	if got := next.(*Model).selection; got != "apps" {
+		t.Errorf("selection = %q, want %q", got, "apps")
+	}
+}
+
+func TestApplyLayoutReportsFailure(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho 'ide.sh: line 3: nvm: command not found' >&2\necho 'second line' >&2\nexit 2\n"
+	if err := os.WriteFile(filepath.Join(dir, "ide.sh"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{client: &tmuxtest.Fake{}, config: config.Config{Layout: "ide", LayoutDir: dir}}
+	m.applyLayout("work", dir)
+
+	want := "Layout 'ide' failed: ide.sh: line 3: nvm: command not found"
+	if m.message != want || !m.messageIsError {
+		t.Errorf("message = %q (error=%v), want %q", m.message, m.messageIsError, want)
+	}
+}
+
+func TestApplyLayoutTimeout(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ide.sh"), []byte("#!/bin/sh\nsleep 10\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{client: &tmuxtest.Fake{}, config: config.Config{Layout: "ide", LayoutDir: dir, LayoutTimeout: "100ms"}}
+	start := time.Now()
+	m.applyLayout("work", dir)
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("applyLayout took %v, want it killed after the timeout", elapsed)
+	}
+	if want := "Layout 'ide' timed out after 100ms"; m.message != want {
+		t.Errorf("message = %q, want %q", m.message, want)
+	}
+}
+
+func TestFirstLine(t *testing.T) {
+	err := errors.New("exit status 1")
+	if got := firstLine([]byte("\n  boom  \nmore\n"), err); got != "boom" {
+		t.Errorf("firstLine = %q, want %q", got, "boom")
+	}
+	if got := firstLine(nil, err); got != "exit status 1" {
+		t.Errorf("firstLine without output = %q, want the error", got)
+	}
+}
+
+func TestMarkedKills(t *testing.T) {
+	m := Model{
+		sessions: []tmux.Session{
+			{Name: "api", Windows: []tmux.Window{{Index: 1}, {Index: 2}}},
+			{Name: "blog", Windows: []tmux.Window{{Index: 1}, {Index: 2}, {Index: 3}}},
+		},
+	}
+	m.items = []Item{
+		{Type: ItemTypeSession, SessionIndex: 0},
+		{Type: ItemTypeWindow, SessionIndex: 0, WindowIndex: 0},
+		{Type: ItemTypeSession, SessionIndex: 1},
+		{Type: ItemTypeWindow, SessionIndex: 1, WindowIndex: 0},
+		{Type: ItemTypeWindow, SessionIndex: 1, WindowIndex: 2},
+	}
+
+	// Space toggles: mark api, its window 1 and two blog windows, then unmark one again
+	for _, cursor := range []int{0, 1, 3, 4, 1} {
+		m.cursor = cursor
+		m.handleKey(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	}
+	if len(m.selected) != 3 || m.selected["api:1"] {
+		t.Fatalf("selected = %v, want api, blog:1, blog:3", m.selected)
+	}
+
+	// Windows go highest index first so renumbering can't shift later targets
+	var got []string
+	for _, k := range m.markedKills() {
+		got = append(got, k.target)
+	}
+	if want := "api blog:3 blog:1"; strings.Join(got, " ") != want {
+		t.Errorf("kill order = %v, want %s", got, want)
+	}
+
+	m.confirmKill()
+	if m.mode != ModeConfirmKill || m.message != "Kill 3 items?" {
+		t.Errorf("mode = %v, message = %q, want confirm with \"Kill 3 items?\"", m.mode, m.message)
+	}
+
+	m.mode = ModeNormal
+	m.selected = map[string]bool{"api": true, "blog": true}
+	m.confirmKill()
+	if m.message != "Kill 2 sessions?" {
+		t.Errorf("message = %q, want %q", m.message, "Kill 2 sessions?")
+	}
+}
+
+// initRepo creates a git repository in dir, skipping the test without git
+func initRepo(t *testing.T, dir string) {
+	t.Helper()
+	if !git.Available() {
+		t.Skip("git is not installed")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("git", "-C", dir, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+}
+
+func TestRemovableDir(t *testing.T) {
+	root := t.TempDir()
+	initRepo(t, filepath.Join(root, "scratch"))
+	m := Model{config: config.Config{ProjectDirs: []string{root}, ProjectDepth: 2}}
+
+	tests := []struct {
+		path string
+		want string // "" = not removable
+	}{
+		{root + "/org/app", root + "/org/app"},
+		{root + "/org/app/src/", root + "/org/app"}, // A pane cd'd into the project
+		{root + "/scratch", root + "/scratch"},      // A repo above project_depth
+		{root + "/org", ""},                         // An organisation directory
+		{root, ""},                                  // The root itself
+		{root + "2/x/y", ""},                        // Only a name prefix
+		{"/", ""},
+	}
+	for _, tt := range tests {
+		got, ok := m.removableDir(tt.path)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("removableDir(%q) = %q, %v, want %q", tt.path, got, ok, tt.want)
+		}
+	}
+}
+
+func TestPickDirectoryMarksOpenSessions(t *testing.T) {
+	m := New("notes", config.Config{ProjectDepth: 1})
+	m.width, m.height = 80, 30
+	m.mode = ModePickDirectory
+	m.sessions = []tmux.Session{{Name: "api"}}
+	m.projectList.SetItems([]string{"/repos/api", "/repos/blog", "/repos/notes"})
+
+	lines := strings.Split(m.viewPickDirectory(), "\n")
+	tagged := map[string]bool{}
+	for _, line := range lines {
+		for _, name := range []string{"api", "blog", "notes"} {
+			if strings.Contains(line, name) {
+				tagged[name] = strings.Contains(line, ui.OpenSessionTag)
+			}
+		}
+	}
+	want := map[string]bool{"api": true, "blog": false, "notes": true}
+	for name, open := range want {
+		if tagged[name] != open {
+			t.Errorf("%s tagged open = %v, want %v", name, tagged[name], open)
+		}
+	}
+}
+
+func TestMaxSessions(t *testing.T) {
+	m := Model{
+		config:   config.Config{MaxSessions: 2},
+		sessions: []tmux.Session{{Name: "api"}, {Name: "blog"}, {Name: "notes"}},
+	}
+
+	m.rebuildItems()
+	if len(m.items) != 2 {
+		t.Fatalf("unfiltered items = %d, want 2 (max_sessions)", len(m.items))
+	}
+	if got := m.stateText(); !strings.Contains(got, "(showing 2 of 3)") {
+		t.Errorf("stateText() = %q, want it to mention the cap", got)
+	}
+
+	// The filter searches beyond the cap
+	m.filter = "notes"
+	m.rebuildItems()
+	if len(m.items) != 1 || m.sessions[m.items[0].SessionIndex].Name != "notes" {
+		t.Errorf("filtered items = %+v, want the capped-out notes session", m.items)
+	}
+}
+
+func TestActiveWithin(t *testing.T) {
+	now := time.Now()
+	m := Model{
+		config: config.Config{ActiveWithin: "24h"},
+		sessions: []tmux.Session{
+			{Name: "api", LastActivity: now.Add(-time.Hour)},
+			{Name: "blog", LastActivity: now.Add(-2 * time.Hour)},
+			{Name: "old", LastActivity: now.Add(-30 * 24 * time.Hour)},
+		},
+	}
+
+	m.rebuildItems()
+	if len(m.items) != 2 || !strings.Contains(m.stateText(), "(showing 2 of 3)") {
+		t.Fatalf("items = %d, state %q: want old hidden", len(m.items), m.stateText())
+	}
+
+	m.filter = "old"
+	m.rebuildItems()
+	if len(m.items) != 1 {
+		t.Errorf("filtered items = %d, want the stale session", len(m.items))
+	}
+
+	m.filter = ""
+	m.handleKey(tea.KeyMsg{Type: tea.KeyF3})
+	if len(m.items) != 3 || m.sessionsCapped() {
+		t.Errorf("after F3: items = %d, want all 3", len(m.items))
+	}
+}
+
+func TestSwitchCommand(t *testing.T) {
+	tests := []struct {
+		form, target, want string
+	}{
+		{"", "api", "tmux switch-client -t api"},
+		{config.YankCommandTmux, "api:2", "tmux switch-client -t api:2"},
+		{config.YankCommandHelm, "api", "helm switch api"},
+		{config.YankCommandHelm, "it's mine", `helm switch 'it'\''s mine'`},
+	}
+	for _, tt := range tests {
+		if got := switchCommand(tt.form, tt.target); got != tt.want {
+			t.Errorf("switchCommand(%q, %q) = %q, want %q", tt.form, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestToggleStar(t *testing.T) {
+	now := time.Now()
+	dir := t.TempDir()
+	m := Model{
+		config: config.Config{CacheDir: dir, ActiveWithin: "24h"},
+		sessions: []tmux.Session{
+			{Name: "api", LastActivity: now.Add(-time.Hour)},
+			{Name: "blog", LastActivity: now.Add(-2 * time.Hour)},
+			{Name: "old", LastActivity: now.Add(-30 * 24 * time.Hour)},
+		},
+	}
+	m.rebuildItems()
+
+	// Starring moves the session to the top, past the active_within cutoff
+	m.filter = "old"
+	m.rebuildItems()
+	m.toggleStar()
+	m.filter = ""
+	m.rebuildItems()
+	if m.sessions[0].Name != "old" || len(m.items) != 3 {
+		t.Fatalf("order %v, items %d: want old first and listed", sessionNames(m.sessions), len(m.items))
+	}
+
+	// Stars are re-applied by name from the file
+	reloaded := Model{config: m.config, sessions: []tmux.Session{{Name: "api"}, {Name: "old"}}}
+	reloaded.starred = reloaded.loadStarred()
+	reloaded.sortStarred()
+	if reloaded.sessions[0].Name != "old" {
+		t.Errorf("reloaded order %v, want old first", sessionNames(reloaded.sessions))
+	}
+
+	// Unstarring returns it to its activity slot
+	m.cursor = 0
+	m.toggleStar()
+	if got := sessionNames(m.sessions); !slices.Equal(got, []string{"api", "blog", "old"}) {
+		t.Errorf("after unstar: order %v", got)
+	}
+}
+
+func sessionNames(sessions []tmux.Session) []string {
+	names := make([]string, len(sessions))
+	for i, s := range sessions {
+		names[i] = s.Name
+	}
+	return names
+}
+
+func TestRemoveDirtyFolderNeedsSecondConfirm(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "api")
+	initRepo(t, dir)
+	for _, name := range []string{"a.go", "b.go", "c.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := Model{
+		client:      &tmuxtest.Fake{},
+		mode:        ModePickDirectory,
+		projectList: newProjectList(config.Config{}),
+	}
+	m.projectList.SetItems([]string{dir})
+	ctrlX := tea.KeyMsg{Type: tea.KeyCtrlX}
+
+	m.confirmRemoveFolder()
+	if m.mode != ModeConfirmRemoveFolder || m.removeDirty != 3 {
+		t.Fatalf("confirm: mode %v, dirty %d: want the dirty repo detected", m.mode, m.removeDirty)
+	}
+
+	m.handleKey(ctrlX)
+	if _, err := os.Stat(dir); err != nil || !m.removeArmed || !strings.Contains(m.message, "3 uncommitted changes") {
+		t.Fatalf("first C-x: dir err %v, armed %v, message %q: want a second prompt", err, m.removeArmed, m.message)
+	}
+	if len(m.messages) == 0 || m.messages[0].Text != m.message || !m.messages[0].IsError {
+		t.Errorf("first C-x: message log %+v, want the warning logged as an error", m.messages)
+	}
+
+	m.handleKey(ctrlX)
+	if _, err := os.Stat(dir); !os.IsNotExist(err) || m.mode != ModePickDirectory {
+		t.Errorf("second C-x: dir err %v, mode %v: want removed", err, m.mode)
+	}
+}
+
+func TestToggleStatusColumns(t *testing.T) {
+	m := Model{config: config.Config{GitStatusEnabled: true}}
+	m.maxGitStatusWidth = ui.GitStatusColumnWidth
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyF6})
+	if m.config.GitStatusEnabled || m.maxGitStatusWidth != 0 || m.message != "Git status off" {
+		t.Errorf("F6: enabled %v, width %d, message %q: want the git column off", m.config.GitStatusEnabled, m.maxGitStatusWidth, m.message)
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyF6})
+	if !m.config.GitStatusEnabled {
+		t.Error("second F6 should turn git status back on")
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyF7})
+	if !m.config.ClaudeStatusEnabled || m.message != "Claude status on" {
+		t.Errorf("F7: enabled %v, message %q: want claude status on", m.config.ClaudeStatusEnabled, m.message)
+	}
+}
+
+func TestClearClaudeStatuses(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"api", "web"} {
+		if err := claude.WriteStatus(name, dir, "working", claude.FormatColon); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := Model{
+		config:   config.Config{CacheDir: dir, ClaudeStatusEnabled: true},
+		sessions: []tmux.Session{{Name: "api"}, {Name: "web"}},
+	}
+	m.loadClaudeStatuses()
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyF8})
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeNormal || len(m.claudeStatuses) != 2 {
+		t.Fatalf("mode %v, %d statuses: Esc should keep the files", m.mode, len(m.claudeStatuses))
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyF8})
+	if m.mode != ModeConfirmClearClaude {
+		t.Fatalf("mode = %v, want the confirmation", m.mode)
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(m.claudeStatuses) != 0 || m.message != "Removed 2 status files" {
+		t.Errorf("%d statuses, message %q: want all cleared", len(m.claudeStatuses), m.message)
+	}
+}
+
+func TestRespawnDeadPanes(t *testing.T) {
+	fake := &tmuxtest.Fake{}
+	_ = fake.CreateSession("api", "/tmp", "go")
+	fake.Sessions[0].Windows[0].Panes[0].Dead = true
+	fake.Sessions[0].Windows[0].Panes = append(fake.Sessions[0].Windows[0].Panes, tmux.Pane{Index: 1, Command: "zsh"})
+	m := Model{client: fake}
+	m.sessions = m.loadSessions().(sessionsMsg).sessions
+	m.rebuildItems()
+
+	if m.sessions[0].DeadPanes != 1 {
+		t.Fatalf("DeadPanes = %d, want 1 from the pane scan", m.sessions[0].DeadPanes)
+	}
+	_, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyF9})
+	if cmd == nil || !fake.Sessions[0].Windows[0].Panes[0].Dead {
+		t.Fatal("F9 should respawn in the background")
+	}
+	next, _ := m.Update(cmd())
+	m = next.(Model)
+	if fake.Sessions[0].Windows[0].Panes[0].Dead || m.message != "Respawned 1 dead pane in api" {
+		t.Errorf("message %q: want the dead pane respawned and the live one left alone", m.message)
+	}
+}
+
+func TestBroadcastToAllPanes(t *testing.T) {
+	fake := &tmuxtest.Fake{}
+	_ = fake.CreateSession("api", "/tmp", "nvim")
+	fake.Sessions[0].Windows = append(fake.Sessions[0].Windows, tmux.Window{Index: 1, Panes: []tmux.Pane{{Index: 0}, {Index: 1, Dead: true}}})
+	m := Model{client: fake, input: textinput.New()}
+	m.sessions = m.loadSessions().(sessionsMsg).sessions
+	m.rebuildItems()
+
+	m.input.CharLimit = createInputLimit
+	m.handleKey(tea.KeyMsg{Type: tea.KeyF4})
+	if m.input.CharLimit != 0 {
+		t.Errorf("CharLimit = %d, want no limit for broadcast commands", m.input.CharLimit)
+	}
+	m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("git pull")})
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeBroadcast || m.broadcastCommand != "git pull" || len(fake.Ran) != 0 {
+		t.Fatalf("mode %v, command %q, ran %v: want a confirmation before sending", m.mode, m.broadcastCommand, fake.Ran)
+	}
+
+	m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	want := []string{"api:0.0: git pull", "api:1.0: git pull"}
+	if !slices.Equal(fake.Ran, want) || m.mode != ModeNormal {
+		t.Errorf("ran %v, want %v (dead pane skipped)", fake.Ran, want)
+	}
+}
+
+func TestSessionWindowPaneTree(t *testing.T) {
+	fake := &tmuxtest.Fake{}
+	_ = fake.CreateSession("api", "/tmp", "nvim")
+	fake.Sessions[0].Windows[0].Panes = append(fake.Sessions[0].Windows[0].Panes, tmux.Pane{Index: 1, Command: "go"})
+	m := Model{client: fake}
+	m.sessions = m.loadSessions().(sessionsMsg).sessions
+	m.rebuildItems()
+
+	m.expandCurrent() // session
+	m.cursor = 1
+	m.expandCurrent() // window
+	if len(m.items) != 4 || m.getTargetName(m.items[3]) != "api:0.1" {
+		t.Fatalf("items = %d, last %q: want session, window and two panes", len(m.items), m.getTargetName(m.items[len(m.items)-1]))
+	}
+
+	// Collapsing an expanded window folds only its panes
+	m.collapseCurrent()
+	if len(m.items) != 2 || !m.sessions[0].Expanded {
+		t.Errorf("after collapsing the window: items = %d, session expanded %v", len(m.items), m.sessions[0].Expanded)
+	}
+	m.collapseCurrent()
+	if len(m.items) != 1 || m.cursor != 0 {
+		t.Errorf("after collapsing again: items = %d, cursor %d: want the session row", len(m.items), m.cursor)
+	}
+}
+
+func TestViewsFillHeight(t *testing.T) {
+	m := New("", config.Config{CacheDir: t.TempDir()})
+	m.width, m.height = 80, 30
+	m.sessions = []tmux.Session{{Name: "api"}, {Name: "blog"}}
+	m.sessionsLoaded = true
+	m.rebuildItems()
+
+	// Long messages and hints are cut, not wrapped, even in a narrow popup
+	m.setError("a long error that would wrap onto a second line in a narrow popup, pushing the footer down")
+	for _, width := range []int{80, 50} {
+		m.width = width
+		for _, mode := range []Mode{ModeNormal, ModeLog, ModeSettings, ModePickDirectory, ModeBookmarks, ModeCloneRepo} {
+			m.mode = mode
+			if got := lipgloss.Height(m.View()); got != m.height {
+				t.Errorf("width %d: %s view is %d lines, want the terminal height %d", width, mode, got, m.height)
+			}
+		}
+	}
+}
+
+func TestResizeKeepsCursorVisible(t *testing.T) {
+	var sessions []tmux.Session
+	var paths []string
+	for i := range 30 {
+		sessions = append(sessions, tmux.Session{Name: fmt.Sprintf("s%d", i)})
+		paths = append(paths, fmt.Sprintf("/p/%d", i))
+	}
+	m := Model{sessions: sessions, sessionsLoaded: true, projectList: newProjectList(config.Config{})}
+	m.projectList.SetItems(paths)
+	m.rebuildItems()
+
+	resize := func(height int) {
+		next, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: height})
+		m = next.(Model)
+	}
+
+	// A short window scrolls down to the cursor
+	resize(15)
+	m.cursor = 20
+	m.updateScrollOffset()
+	m.projectList.SetCursor(20)
+	if m.scrollOffset == 0 || m.projectList.ScrollOffset() == 0 {
+		t.Fatalf("offsets %d / %d: want scrolled", m.scrollOffset, m.projectList.ScrollOffset())
+	}
+
+	// Shrinking further keeps the cursor on screen
+	resize(13)
+	if end := m.sessionVisibleEnd(m.scrollOffset); m.cursor < m.scrollOffset || m.cursor >= end {
+		t.Errorf("after shrinking: cursor %d outside rows %d-%d", m.cursor, m.scrollOffset, end)
+	}
+
+	// Growing tall enough for every row scrolls back to the top
+	resize(60)
+	if m.scrollOffset != 0 || m.projectList.ScrollOffset() != 0 {
+		t.Errorf("after growing: offsets %d / %d, want 0", m.scrollOffset, m.projectList.ScrollOffset())
+	}
+}
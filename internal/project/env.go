@@ -0,0 +1,112 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultEnvFiles are the version files EnvVersions reads
+var DefaultEnvFiles = []string{".nvmrc", ".node-version", ".python-version", ".ruby-version", ".tool-versions"}
+
+// EnvVersions returns the runtime versions pinned in path by DefaultEnvFiles,
+// keyed by tool (e.g. "node" -> "20.11.0")
+func EnvVersions(path string) map[string]string {
+	return readEnvVersions(path, DefaultEnvFiles)
+}
+
+// EnvReader reads pinned runtime versions with a custom file list, caching results per path
+// Safe for concurrent use
+type EnvReader struct {
+	files []string
+
+	mu    sync.Mutex
+	cache map[string]map[string]string
+}
+
+// NewEnvReader creates a reader; an empty file list means DefaultEnvFiles
+func NewEnvReader(files []string) *EnvReader {
+	if len(files) == 0 {
+		files = DefaultEnvFiles
+	}
+	return &EnvReader{files: files, cache: make(map[string]map[string]string)}
+}
+
+// Versions returns the versions pinned in path, read once per path
+func (r *EnvReader) Versions(path string) map[string]string {
+	r.mu.Lock()
+	versions, cached := r.cache[path]
+	r.mu.Unlock()
+	if cached {
+		return versions
+	}
+
+	versions = readEnvVersions(path, r.files)
+	r.mu.Lock()
+	r.cache[path] = versions
+	r.mu.Unlock()
+	return versions
+}
+
+// EnvBadge formats versions as a compact "node@20.11.0 python@3.12" badge, sorted by tool
+func EnvBadge(versions map[string]string) string {
+	tools := make([]string, 0, len(versions))
+	for tool := range versions {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	parts := make([]string, len(tools))
+	for i, tool := range tools {
+		parts[i] = tool + "@" + versions[tool]
+	}
+	return strings.Join(parts, " ")
+}
+
+// readEnvVersions reads each file in order; earlier files win for the same tool
+func readEnvVersions(path string, files []string) map[string]string {
+	versions := make(map[string]string)
+	if path == "" {
+		return versions
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(path, file))
+		if err != nil {
+			continue
+		}
+		for tool, version := range parseEnvFile(file, string(data)) {
+			if _, ok := versions[tool]; !ok {
+				versions[tool] = version
+			}
+		}
+	}
+	return versions
+}
+
+// parseEnvFile parses .tool-versions ("tool version" per line) or a single-version
+// file, whose tool is derived from the name (.nvmrc = node, .python-version = python)
+func parseEnvFile(file, content string) map[string]string {
+	versions := make(map[string]string)
+	if file == ".tool-versions" {
+		for _, line := range strings.Split(content, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 && !strings.HasPrefix(fields[0], "#") {
+				versions[fields[0]] = fields[1]
+			}
+		}
+		return versions
+	}
+
+	version, _, _ := strings.Cut(strings.TrimSpace(content), "\n")
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "" {
+		return versions
+	}
+	tool := strings.TrimSuffix(strings.TrimPrefix(file, "."), "-version")
+	if file == ".nvmrc" {
+		tool = "node"
+	}
+	versions[tool] = version
+	return versions
+}
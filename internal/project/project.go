@@ -0,0 +1,86 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Marker identifies a project type by a file in the project root
+type Marker struct {
+	File string `yaml:"file"` // e.g. "go.mod"
+	Type string `yaml:"type"` // e.g. "go"
+	Icon string `yaml:"icon"` // Glyph or short label shown in the session row
+}
+
+// DefaultMarkers are checked in order; the first file found wins
+var DefaultMarkers = []Marker{
+	{File: "go.mod", Type: "go", Icon: "go"},
+	{File: "Cargo.toml", Type: "rust", Icon: "rs"},
+	{File: "package.json", Type: "js", Icon: "js"},
+	{File: "pyproject.toml", Type: "python", Icon: "py"},
+	{File: "requirements.txt", Type: "python", Icon: "py"},
+	{File: "Gemfile", Type: "ruby", Icon: "rb"},
+	{File: "mix.exs", Type: "elixir", Icon: "ex"},
+	{File: "flake.nix", Type: "nix", Icon: "nx"},
+	{File: "init.lua", Type: "lua", Icon: "lu"},
+	{File: "Makefile", Type: "make", Icon: "mk"},
+}
+
+// DetectType returns the type of the project at path using DefaultMarkers ("" if unknown)
+func DetectType(path string) string {
+	m, _ := detect(path, DefaultMarkers)
+	return m.Type
+}
+
+// Detector detects project types with a custom marker list, caching results per path
+// Safe for concurrent use
+type Detector struct {
+	markers []Marker
+
+	mu    sync.Mutex
+	cache map[string]Marker
+}
+
+// NewDetector creates a detector; an empty marker list means DefaultMarkers
+func NewDetector(markers []Marker) *Detector {
+	if len(markers) == 0 {
+		markers = DefaultMarkers
+	}
+	return &Detector{markers: markers, cache: make(map[string]Marker)}
+}
+
+// Markers returns the markers the detector checks
+func (d *Detector) Markers() []Marker {
+	return d.markers
+}
+
+// Detect returns the first marker found in path
+// Unknown paths are cached too, so each path is only stat'ed once
+func (d *Detector) Detect(path string) (Marker, bool) {
+	d.mu.Lock()
+	m, cached := d.cache[path]
+	d.mu.Unlock()
+	if cached {
+		return m, m.Type != ""
+	}
+
+	m, ok := detect(path, d.markers)
+	d.mu.Lock()
+	d.cache[path] = m
+	d.mu.Unlock()
+	return m, ok
+}
+
+// detect stats each marker file in order
+func detect(path string, markers []Marker) (Marker, bool) {
+	if path == "" {
+		return Marker{}, false
+	}
+	for _, m := range markers {
+		if _, err := os.Stat(filepath.Join(path, m.File)); err == nil {
+			return m, true
+		}
+	}
+	return Marker{}, false
+}
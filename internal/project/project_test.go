@@ -0,0 +1,85 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectType(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if got := DetectType(tmpDir); got != "" {
+		t.Errorf("DetectType(empty dir) = %q, want \"\"", got)
+	}
+
+	// go.mod is listed before Makefile, so it wins
+	for _, name := range []string{"Makefile", "go.mod"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := DetectType(tmpDir); got != "go" {
+		t.Errorf("DetectType() = %q, want %q", got, "go")
+	}
+}
+
+func TestDetectorCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	d := NewDetector([]Marker{{File: "deno.json", Type: "deno", Icon: "dn"}})
+
+	if _, ok := d.Detect(tmpDir); ok {
+		t.Fatal("Detect() on empty dir should find nothing")
+	}
+
+	// Results are cached per path: a file added later is not seen
+	if err := os.WriteFile(filepath.Join(tmpDir, "deno.json"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.Detect(tmpDir); ok {
+		t.Error("Detect() should return the cached result")
+	}
+
+	other := t.TempDir()
+	if err := os.WriteFile(filepath.Join(other, "deno.json"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if m, ok := d.Detect(other); !ok || m.Icon != "dn" {
+		t.Errorf("Detect() = %+v, %v, want deno marker", m, ok)
+	}
+}
+
+func TestEnvVersions(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		".nvmrc":          "v20.11.0\n",
+		".python-version": "3.12.1\n",
+		".tool-versions":  "# pinned\nnodejs 18.0.0\ngolang 1.22.0\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := EnvVersions(tmpDir)
+	want := map[string]string{"node": "20.11.0", "python": "3.12.1", "nodejs": "18.0.0", "golang": "1.22.0"}
+	if len(got) != len(want) {
+		t.Fatalf("EnvVersions() = %v, want %v", got, want)
+	}
+	for tool, version := range want {
+		if got[tool] != version {
+			t.Errorf("EnvVersions()[%q] = %q, want %q", tool, got[tool], version)
+		}
+	}
+
+	if badge := EnvBadge(map[string]string{"python": "3.12", "node": "20"}); badge != "node@20 python@3.12" {
+		t.Errorf("EnvBadge() = %q, want %q", badge, "node@20 python@3.12")
+	}
+
+	// A custom file list only reads those files
+	r := NewEnvReader([]string{".python-version"})
+	if got := r.Versions(tmpDir); len(got) != 1 || got["python"] != "3.12.1" {
+		t.Errorf("Versions() = %v, want only python", got)
+	}
+}
@@ -0,0 +1,16 @@
+// Package shell builds command lines for sh, as run by tmux run-shell, popups
+// and send-keys
+package shell
+
+import "strings"
+
+// Quote single-quotes s unless it only has characters that are safe unquoted
+func Quote(s string) string {
+	safe := s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.:/=@+", r))
+	}) < 0
+	if safe {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
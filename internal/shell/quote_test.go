@@ -0,0 +1,21 @@
+package shell
+
+import "testing"
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"api", "api"},
+		{"~/repos/org/app", `'~/repos/org/app'`},
+		{"/tmp/a b", `'/tmp/a b'`},
+		{"it's", `'it'\''s'`},
+		{"$HOME", `'$HOME'`},
+		{"", "''"},
+	}
+	for _, tt := range tests {
+		if got := Quote(tt.in); got != tt.want {
+			t.Errorf("Quote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package tmux
+
+// Client is the set of tmux operations the TUI uses, mirroring the package
+// functions so the model can be tested against tmuxtest.Fake instead of a tmux server
+type Client interface {
+	ListSessions(excludeCurrent string, includePopups bool) ([]Session, error)
+	WindowCounts() (map[string]int, error)
+	ScanPanes(commands []string) (PaneScan, error)
+	ListWindows(sessionName string) ([]Window, error)
+	LastActiveWindow(sessionName string) (int, error)
+	ListPanes(sessionName string, windowIndex int) ([]Pane, error)
+	SessionExists(name string) bool
+	CreateSession(name, dir, command string) error
+	RunInSession(sessionName, command string) error
+	SendKeysAll(sessionName, command string) (int, error)
+	KillSession(name string) error
+	KillWindow(sessionName string, windowIndex int) error
+	KillPane(sessionName string, windowIndex, paneIndex int) error
+	RespawnDeadPanes(sessionName string) (int, error)
+	MoveWindow(sessionName string, windowIndex int, dstSession string) error
+	LinkWindow(sessionName string, windowIndex int, dstSession string) error
+	SwitchClient(target string) error
+	DisplayMessage(message string) error
+	DisplayPath(target string) (string, error)
+	RunShell(script string) error
+	GlobalEnv(name string) (string, error)
+}
+
+// ExecClient runs the package functions, each of which shells out to the tmux binary
+type ExecClient struct{}
+
+var _ Client = ExecClient{}
+
+func (ExecClient) ListSessions(excludeCurrent string, includePopups bool) ([]Session, error) {
+	return ListSessions(excludeCurrent, includePopups)
+}
+
+func (ExecClient) WindowCounts() (map[string]int, error) { return WindowCounts() }
+
+func (ExecClient) ScanPanes(commands []string) (PaneScan, error) { return ScanPanes(commands) }
+
+func (ExecClient) ListWindows(sessionName string) ([]Window, error) { return ListWindows(sessionName) }
+
+func (ExecClient) LastActiveWindow(sessionName string) (int, error) {
+	return LastActiveWindow(sessionName)
+}
+
+func (ExecClient) ListPanes(sessionName string, windowIndex int) ([]Pane, error) {
+	return ListPanes(sessionName, windowIndex)
+}
+
+func (ExecClient) SessionExists(name string) bool { return SessionExists(name) }
+
+func (ExecClient) CreateSession(name, dir, command string) error {
+	return CreateSession(name, dir, command)
+}
+
+func (ExecClient) RunInSession(sessionName, command string) error {
+	return RunInSession(sessionName, command)
+}
+
+func (ExecClient) SendKeysAll(sessionName, command string) (int, error) {
+	return SendKeysAll(sessionName, command)
+}
+
+func (ExecClient) KillSession(name string) error { return KillSession(name) }
+
+func (ExecClient) KillWindow(sessionName string, windowIndex int) error {
+	return KillWindow(sessionName, windowIndex)
+}
+
+func (ExecClient) KillPane(sessionName string, windowIndex, paneIndex int) error {
+	return KillPane(sessionName, windowIndex, paneIndex)
+}
+
+func (ExecClient) RespawnDeadPanes(sessionName string) (int, error) {
+	return RespawnDeadPanes(sessionName)
+}
+
+func (ExecClient) MoveWindow(sessionName string, windowIndex int, dstSession string) error {
+	return MoveWindow(sessionName, windowIndex, dstSession)
+}
+
+func (ExecClient) LinkWindow(sessionName string, windowIndex int, dstSession string) error {
+	return LinkWindow(sessionName, windowIndex, dstSession)
+}
+
+func (ExecClient) SwitchClient(target string) error { return SwitchClient(target) }
+
+func (ExecClient) DisplayMessage(message string) error { return DisplayMessage(message) }
+
+func (ExecClient) DisplayPath(target string) (string, error) { return DisplayPath(target) }
+
+func (ExecClient) RunShell(script string) error { return RunShell(script) }
+
+func (ExecClient) GlobalEnv(name string) (string, error) { return GlobalEnv(name) }
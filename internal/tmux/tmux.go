@@ -1,51 +1,174 @@
 package tmux
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/black-atom-industries/helm/internal/log"
 )
 
 // Session represents a tmux session
 type Session struct {
 	Name         string
 	LastActivity time.Time
+	Created      time.Time
+	Attached     int      // Number of clients attached to the session
+	Group        string   // tmux session group ("" if ungrouped)
+	GroupSize    int      // Sessions in the group, including this one (0 if ungrouped)
+	GroupMembers []string // Names of every session in the group, most active first (nil if ungrouped)
+	WindowCount  int      // Number of windows (0 if unknown; see WindowCounts)
+	Watched      bool     // A pane runs a watched command (see ScanPanes)
+	PaneMarked   bool     // Holds tmux's marked pane (select-pane -m; see ScanPanes)
+	PaneCommand  string   // Current command of the active pane (see ScanPanes)
+	PaneTitle    string   // Title of the active pane, "" if unset or the default host name
+	DeadPanes    int      // Panes whose process exited, kept by remain-on-exit (see ScanPanes)
 	Windows      []Window
 	Expanded     bool
 }
 
 // Window represents a tmux window
 type Window struct {
-	Index    int
-	Name     string
-	Panes    []Pane
-	Expanded bool
+	Index        int
+	Name         string
+	LastActivity time.Time
+	Panes        []Pane
+	Expanded     bool
 }
 
 // Pane represents a tmux pane
 type Pane struct {
 	Index   int
 	Command string // Current command running in the pane
+	Title   string // Pane title (only filled in by ScanPanes)
 	Active  bool   // Active pane in the window
+	Dead    bool   // Process exited; the pane is kept by remain-on-exit (see RespawnDeadPanes)
+}
+
+// socketArgs select the tmux server every command talks to (see SetSocket)
+var socketArgs []string
+
+// SetSocket points every tmux command at another server: a socket name is
+// passed as -L, a path (containing "/") as -S. "" restores the default server
+func SetSocket(socket string) {
+	socketArgs = SocketArgs(socket)
+}
+
+// SocketArgs returns the tmux flags selecting socket, nil for ""
+func SocketArgs(socket string) []string {
+	switch {
+	case socket == "":
+		return nil
+	case strings.Contains(socket, "/"):
+		return []string{"-S", socket}
+	default:
+		return []string{"-L", socket}
+	}
+}
+
+// InCurrentServer reports whether helm runs inside a client of the server set by
+// SetSocket, so switch-client works; otherwise SwitchClient attaches instead
+func InCurrentServer() bool {
+	return inServer(os.Getenv("TMUX"), socketArgs)
+}
+
+// inServer matches the socket path in $TMUX ("path,pid,session") against socket flags
+func inServer(env string, args []string) bool {
+	if env == "" {
+		return false
+	}
+	if len(args) != 2 {
+		return true
+	}
+	path, _, _ := strings.Cut(env, ",")
+	if args[0] == "-S" {
+		return path == args[1]
+	}
+	return filepath.Base(path) == args[1]
+}
+
+// Command builds a tmux invocation on the server set by SetSocket
+func Command(args ...string) *exec.Cmd {
+	return exec.Command("tmux", append(append([]string{}, socketArgs...), args...)...)
+}
+
+// output runs a tmux command and returns its stdout, logging the call and how
+// long it took to the debug log
+// tmux explains failures such as "can't find session" on stderr, so that text
+// goes into the error; stderr from a successful call is logged as a warning
+func output(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := Command(args...)
+	cmd.Stderr = &stderr
+	start := time.Now()
+	out, err := cmd.Output()
+	err = commandError(args, stderr.String(), err)
+
+	call := "tmux " + strings.Join(args, " ")
+	switch {
+	case err != nil:
+		log.Printf("%s: %v (%s)", call, err, time.Since(start).Round(time.Microsecond))
+	case strings.TrimSpace(stderr.String()) != "":
+		log.Printf("%s: warning: %s", call, strings.TrimSpace(stderr.String()))
+	default:
+		log.Since(call, start)
+	}
+	return out, err
+}
+
+// commandError adds tmux's stderr to the error of a failed command, e.g.
+// "tmux kill-session: can't find session: x (exit status 1)"
+func commandError(args []string, stderr string, err error) error {
+	stderr = strings.TrimSpace(stderr)
+	if err == nil || stderr == "" {
+		return err
+	}
+	name := "tmux"
+	if len(args) > 0 {
+		name += " " + args[0]
+	}
+	return fmt.Errorf("%s: %s (%w)", name, stderr, err)
+}
+
+// run runs a tmux command for its side effect (see output)
+func run(args ...string) error {
+	_, err := output(args...)
+	return err
 }
 
 // CurrentSession returns the name of the current tmux session
+// Returns "" when helm runs in a client of a different server than SetSocket's
 func CurrentSession() (string, error) {
-	out, err := exec.Command("tmux", "display-message", "-p", "#S").Output()
+	if os.Getenv("TMUX") != "" && !InCurrentServer() {
+		return "", nil
+	}
+	out, err := output("display-message", "-p", "#S")
 	if err != nil {
 		return "", err
 	}
 	return strings.TrimSpace(string(out)), nil
 }
 
+// PopupPrefix starts the names of sessions backing tmux popups, hidden by default
+const PopupPrefix = "_popup_"
+
+// IsPopup reports whether name is a popup session (see PopupPrefix)
+func IsPopup(name string) bool {
+	return strings.HasPrefix(name, PopupPrefix)
+}
+
 // ListSessions returns all tmux sessions sorted by activity (most recent first)
-// Excludes the current session and popup sessions
-func ListSessions(excludeCurrent string) ([]Session, error) {
-	out, err := exec.Command("tmux", "list-sessions", "-F", "#{session_activity} #{session_name}").Output()
+// Excludes the current session and, unless includePopups is set, popup sessions.
+// Sessions in the same group share their windows, so each group is listed once
+// (see CollapseGroups)
+func ListSessions(excludeCurrent string, includePopups bool) ([]Session, error) {
+	out, err := output("list-sessions", "-F", "#{session_activity} #{session_created} #{session_attached} #{session_group} #{session_name}")
 	if err != nil {
 		return nil, err
 	}
@@ -58,15 +181,16 @@ func ListSessions(excludeCurrent string) ([]Session, error) {
 	var sessions []Session
 
 	for _, line := range lines {
-		parts := strings.SplitN(line, " ", 2)
-		if len(parts) != 2 {
+		// session_group is empty for ungrouped sessions, leaving an empty field
+		parts := strings.SplitN(line, " ", 5)
+		if len(parts) != 5 {
 			continue
 		}
 
-		name := parts[1]
+		name := parts[4]
 
 		// Skip current session and popup sessions
-		if name == excludeCurrent || strings.HasPrefix(name, "_popup_") {
+		if name == excludeCurrent || (!includePopups && IsPopup(name)) {
 			continue
 		}
 
@@ -74,10 +198,15 @@ func ListSessions(excludeCurrent string) ([]Session, error) {
 		if err != nil {
 			continue
 		}
+		createdUnix, _ := strconv.ParseInt(parts[1], 10, 64)
+		attached, _ := strconv.Atoi(parts[2])
 
 		sessions = append(sessions, Session{
 			Name:         name,
 			LastActivity: time.Unix(activityUnix, 0),
+			Created:      time.Unix(createdUnix, 0),
+			Attached:     attached,
+			Group:        parts[3],
 		})
 	}
 
@@ -86,12 +215,114 @@ func ListSessions(excludeCurrent string) ([]Session, error) {
 		return sessions[i].LastActivity.After(sessions[j].LastActivity)
 	})
 
-	return sessions, nil
+	return CollapseGroups(sessions), nil
 }
 
-// ListWindows returns all windows for a given session
+// CollapseGroups keeps only the first session of each session group and records
+// the group's size and members on it. Ungrouped sessions are kept as they are
+func CollapseGroups(sessions []Session) []Session {
+	members := make(map[string][]string)
+	for _, s := range sessions {
+		if s.Group != "" {
+			members[s.Group] = append(members[s.Group], s.Name)
+		}
+	}
+
+	collapsed := make([]Session, 0, len(sessions))
+	seen := make(map[string]bool)
+	for _, s := range sessions {
+		if s.Group != "" {
+			if seen[s.Group] {
+				continue
+			}
+			seen[s.Group] = true
+			s.GroupMembers = members[s.Group]
+			s.GroupSize = len(s.GroupMembers)
+		}
+		collapsed = append(collapsed, s)
+	}
+	return collapsed
+}
+
+// WindowCounts returns the number of windows per session name
+// Uses a single list-windows -a call instead of one ListWindows per session
+func WindowCounts() (map[string]int, error) {
+	out, err := output("list-windows", "-a", "-F", "#{session_name}")
+	if err != nil {
+		return nil, err
+	}
+	return countLines(string(out)), nil
+}
+
+// PaneScan is what ScanPanes found across all panes
+type PaneScan struct {
+	Running       map[string]bool // Sessions with a pane running one of the watched commands
+	MarkedSession string          // Session holding the marked pane ("" if none)
+	Active        map[string]Pane // Active pane of each session's active window (Command and Title)
+	Dead          map[string]int  // Number of dead panes per session (only sessions with any)
+}
+
+// ScanPanes finds the sessions with a pane whose current command is one of
+// commands, the session holding the marked pane, each session's active pane
+// and how many dead panes each session has
+// Uses a single list-panes -a call instead of one ListPanes per window
+func ScanPanes(commands []string) (PaneScan, error) {
+	format := "#{session_name}\t#{pane_marked}\t#{&&:#{window_active},#{pane_active}}\t#{pane_current_command}\t#{pane_dead}\t#{host}\t#{pane_title}"
+	out, err := output("list-panes", "-a", "-F", format)
+	if err != nil {
+		return PaneScan{}, err
+	}
+	return parsePaneScan(string(out), commands), nil
+}
+
+// parsePaneScan parses "session\tmarked\tactive\tcommand\tdead\thost\ttitle" lines
+// A title equal to the host name is tmux's default and is dropped
+func parsePaneScan(out string, commands []string) PaneScan {
+	watched := make(map[string]bool, len(commands))
+	for _, c := range commands {
+		watched[c] = true
+	}
+	scan := PaneScan{Running: make(map[string]bool), Active: make(map[string]Pane), Dead: make(map[string]int)}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, "\t", 7)
+		if len(fields) != 7 {
+			continue
+		}
+		session, command, dead := fields[0], fields[3], fields[4] == "1"
+		if fields[1] == "1" {
+			scan.MarkedSession = session
+		}
+		if watched[command] {
+			scan.Running[session] = true
+		}
+		if dead {
+			scan.Dead[session]++
+		}
+		if fields[2] == "1" {
+			title := fields[6]
+			if title == fields[5] {
+				title = ""
+			}
+			scan.Active[session] = Pane{Command: command, Title: title, Active: true, Dead: dead}
+		}
+	}
+	return scan
+}
+
+// countLines counts how often each non-empty line occurs
+func countLines(out string) map[string]int {
+	counts := make(map[string]int)
+	for _, line := range strings.Split(out, "\n") {
+		if line != "" {
+			counts[line]++
+		}
+	}
+	return counts
+}
+
+// ListWindows returns all windows for a given session, in index order
 func ListWindows(sessionName string) ([]Window, error) {
-	out, err := exec.Command("tmux", "list-windows", "-t", sessionName, "-F", "#{window_index}:#{window_name}").Output()
+	out, err := output("list-windows", "-t", ExactTarget(sessionName), "-F", "#{window_index}:#{window_activity}:#{window_name}")
 	if err != nil {
 		return nil, err
 	}
@@ -103,8 +334,9 @@ func ListWindows(sessionName string) ([]Window, error) {
 
 	var windows []Window
 	for _, line := range lines {
-		parts := strings.SplitN(line, ":", 2)
-		if len(parts) != 2 {
+		// Window names may contain ":", so they come last
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
 			continue
 		}
 
@@ -112,63 +344,255 @@ func ListWindows(sessionName string) ([]Window, error) {
 		if err != nil {
 			continue
 		}
+		activityUnix, _ := strconv.ParseInt(parts[1], 10, 64)
 
 		windows = append(windows, Window{
-			Index: index,
-			Name:  parts[1],
+			Index:        index,
+			Name:         parts[2],
+			LastActivity: time.Unix(activityUnix, 0),
 		})
 	}
 
 	return windows, nil
 }
 
+// SortWindowsByActivity orders windows by activity (most recent first),
+// keeping index order between windows with the same activity
+func SortWindowsByActivity(windows []Window) {
+	sort.SliceStable(windows, func(i, j int) bool {
+		return windows[i].LastActivity.After(windows[j].LastActivity)
+	})
+}
+
+// LastActiveWindow returns the index of the session's last (previously active) window,
+// the one tmux's last-window command would select. Falls back to the most recently
+// active window other than the current one when no last window is set.
+func LastActiveWindow(sessionName string) (int, error) {
+	out, err := output("list-windows", "-t", ExactTarget(sessionName), "-F", "#{window_index} #{window_last_flag} #{window_active} #{window_activity}")
+	if err != nil {
+		return 0, err
+	}
+
+	best, bestActivity := -1, int64(-1)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		parts := strings.Fields(line)
+		if len(parts) != 4 {
+			continue
+		}
+		index, err := strconv.Atoi(parts[0])
+		if err != nil {
+			continue
+		}
+		if parts[1] == "1" {
+			return index, nil
+		}
+		activity, _ := strconv.ParseInt(parts[3], 10, 64)
+		if parts[2] != "1" && activity > bestActivity {
+			best, bestActivity = index, activity
+		}
+	}
+
+	if best < 0 {
+		return 0, fmt.Errorf("no other window in session %s", sessionName)
+	}
+	return best, nil
+}
+
 // KillSession kills a tmux session by name
 func KillSession(name string) error {
-	return exec.Command("tmux", "kill-session", "-t", name).Run()
+	return run("kill-session", "-t", ExactTarget(name))
 }
 
 // KillWindow kills a tmux window
 func KillWindow(sessionName string, windowIndex int) error {
-	target := fmt.Sprintf("%s:%d", sessionName, windowIndex)
-	return exec.Command("tmux", "kill-window", "-t", target).Run()
+	target := WindowTarget(sessionName, windowIndex)
+	return run("kill-window", "-t", target)
+}
+
+// MoveWindow moves a window into another session.
+// The window takes the next free index in the destination session.
+func MoveWindow(sessionName string, windowIndex int, dstSession string) error {
+	src := WindowTarget(sessionName, windowIndex)
+	return run("move-window", "-s", src, "-t", ExactTarget(dstSession)+":")
+}
+
+// LinkWindow links a window into another session, so it appears in both.
+// The link takes the next free index in the destination session.
+func LinkWindow(sessionName string, windowIndex int, dstSession string) error {
+	src := WindowTarget(sessionName, windowIndex)
+	return run("link-window", "-s", src, "-t", ExactTarget(dstSession)+":")
 }
 
 // SessionExists checks if a tmux session with the given name exists
 func SessionExists(name string) bool {
-	return exec.Command("tmux", "has-session", "-t", name).Run() == nil
+	return run("has-session", "-t", ExactTarget(name)) == nil
 }
 
 // CreateSession creates a new tmux session
-func CreateSession(name, dir string) error {
-	return exec.Command("tmux", "new-session", "-d", "-s", name, "-c", dir).Run()
+// command is what the first window runs instead of the default shell ("" = default)
+func CreateSession(name, dir, command string) error {
+	args := []string{"new-session", "-d", "-s", name, "-c", dir}
+	if command != "" {
+		args = append(args, command)
+	}
+	return run(args...)
+}
+
+// CreateWindow adds a window named name to a session, starting in dir.
+// Returns the index tmux assigned to the new window.
+func CreateWindow(sessionName, name, dir string) (int, error) {
+	out, err := output("new-window", "-d", "-t", ExactTarget(sessionName)+":", "-n", name, "-c", dir, "-P", "-F", "#{window_index}")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(out)))
+}
+
+// RenameWindow renames a tmux window
+func RenameWindow(sessionName string, windowIndex int, name string) error {
+	target := WindowTarget(sessionName, windowIndex)
+	return run("rename-window", "-t", target, name)
+}
+
+// SendKeys types command into a window's active pane and presses Enter
+func SendKeys(sessionName string, windowIndex int, command string) error {
+	return sendLine(WindowTarget(sessionName, windowIndex), command)
+}
+
+// sendLine types command literally into target's pane, then presses Enter
+// Without -l a command that happens to be a key name ("Enter", "C-c") would be
+// sent as that key instead of its text
+func sendLine(target, command string) error {
+	if err := run("send-keys", "-l", "-t", target, "--", command); err != nil {
+		return err
+	}
+	return run("send-keys", "-t", target, "Enter")
+}
+
+// SendKeysAll types command into every live pane of a session, in all its
+// windows, and presses Enter in each; returns how many panes it was sent to
+// Dead panes (remain-on-exit) are skipped since they can't take input
+func SendKeysAll(sessionName, command string) (int, error) {
+	out, err := output("list-panes", "-s", "-t", ExactTarget(sessionName), "-F", "#{pane_id}\t#{pane_dead}")
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		id, dead, ok := strings.Cut(line, "\t")
+		if !ok || dead == "1" {
+			continue
+		}
+		if err := sendLine(id, command); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// RunInSession types command into the session's first window (see SendKeys)
+// Unlike a CreateSession command, the shell stays open after the command exits
+func RunInSession(sessionName, command string) error {
+	windows, err := ListWindows(sessionName)
+	if err != nil {
+		return err
+	}
+	if len(windows) == 0 {
+		return fmt.Errorf("session %s has no windows", sessionName)
+	}
+	return SendKeys(sessionName, windows[0].Index, command)
+}
+
+// ExactTarget prefixes a target with "=" so tmux matches its session name exactly.
+// Without it tmux falls back to prefix and pattern matching, so "api" could
+// resolve to "api-v2" once "api" is gone
+func ExactTarget(target string) string {
+	if strings.HasPrefix(target, "=") {
+		return target
+	}
+	return "=" + target
+}
+
+// WindowTarget returns the exact-match target for a window ("=session:index")
+func WindowTarget(sessionName string, windowIndex int) string {
+	return fmt.Sprintf("=%s:%d", sessionName, windowIndex)
+}
+
+// PaneTarget returns the exact-match target for a pane ("=session:window.pane")
+func PaneTarget(sessionName string, windowIndex, paneIndex int) string {
+	return fmt.Sprintf("=%s:%d.%d", sessionName, windowIndex, paneIndex)
 }
 
 // SwitchClient switches the tmux client to a session or window.
-// If running inside tmux, uses switch-client. If outside, uses attach-session.
+// If running inside a client of the same server, uses switch-client. Otherwise
+// (outside tmux, or another server's client) uses attach-session.
+// The session part of target is matched exactly (see ExactTarget)
 func SwitchClient(target string) error {
-	var cmd *exec.Cmd
-	if os.Getenv("TMUX") != "" {
-		cmd = exec.Command("tmux", "switch-client", "-t", target)
-	} else {
-		cmd = exec.Command("tmux", "attach-session", "-t", target)
-		// Connect terminal for interactive attach
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+	target = ExactTarget(target)
+	if InCurrentServer() {
+		return run("switch-client", "-t", target)
 	}
+	cmd := Command("attach-session", "-t", target)
+	// Connect terminal for interactive attach; from a client of another server
+	// this nests, which tmux only allows with $TMUX unset
+	cmd.Env = append(os.Environ(), "TMUX=")
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	log.Printf("tmux attach-session -t %s", target)
 	return cmd.Run()
 }
 
+// DisplayMessage shows a message in the status line of the current client
+func DisplayMessage(message string) error {
+	return run("display-message", message)
+}
+
+// DisplayPath returns the current working directory of the active pane of a
+// session, or of a window or pane target ("session:window[.pane]")
+func DisplayPath(target string) (string, error) {
+	// "=" makes tmux match the session name exactly instead of by prefix
+	out, err := output("display-message", "-t", "="+target, "-p", "#{pane_current_path}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// RunShell starts script with run-shell -b on the tmux server without waiting,
+// so it keeps running (e.g. to open a popup) after helm exits
+func RunShell(script string) error {
+	args := []string{"run-shell", "-b", script}
+	log.Printf("tmux %s", strings.Join(args, " "))
+	return Command(args...).Start()
+}
+
+// GlobalEnv returns name from the tmux server's global environment, which the
+// shells it starts (popups, run-shell) inherit instead of the caller's
+func GlobalEnv(name string) (string, error) {
+	out, err := output("show-environment", "-g", name)
+	if err != nil {
+		return "", err
+	}
+	// A removed variable is listed as "-NAME"
+	_, value, ok := strings.Cut(strings.TrimSpace(string(out)), "=")
+	if !ok {
+		return "", fmt.Errorf("%s is not set in the tmux environment", name)
+	}
+	return value, nil
+}
+
 // SelectWindow selects a specific window in the current client
 func SelectWindow(sessionName string, windowIndex int) error {
-	target := fmt.Sprintf("%s:%d", sessionName, windowIndex)
-	return exec.Command("tmux", "switch-client", "-t", target).Run()
+	target := WindowTarget(sessionName, windowIndex)
+	return run("switch-client", "-t", target)
 }
 
 // ListPanes returns all panes for a given session and window
 func ListPanes(sessionName string, windowIndex int) ([]Pane, error) {
-	target := fmt.Sprintf("%s:%d", sessionName, windowIndex)
-	out, err := exec.Command("tmux", "list-panes", "-t", target, "-F", "#{pane_index}:#{pane_current_command}:#{pane_active}").Output()
+	target := WindowTarget(sessionName, windowIndex)
+	out, err := output("list-panes", "-t", target, "-F", "#{pane_index}:#{pane_active}:#{pane_dead}:#{pane_current_command}")
 	if err != nil {
 		return nil, err
 	}
@@ -180,8 +604,8 @@ func ListPanes(sessionName string, windowIndex int) ([]Pane, error) {
 
 	var panes []Pane
 	for _, line := range lines {
-		parts := strings.SplitN(line, ":", 3)
-		if len(parts) != 3 {
+		parts := strings.SplitN(line, ":", 4)
+		if len(parts) != 4 {
 			continue
 		}
 
@@ -190,12 +614,11 @@ func ListPanes(sessionName string, windowIndex int) ([]Pane, error) {
 			continue
 		}
 
-		active := parts[2] == "1"
-
 		panes = append(panes, Pane{
 			Index:   index,
-			Command: parts[1],
-			Active:  active,
+			Command: parts[3],
+			Active:  parts[1] == "1",
+			Dead:    parts[2] == "1",
 		})
 	}
 
@@ -204,12 +627,34 @@ func ListPanes(sessionName string, windowIndex int) ([]Pane, error) {
 
 // KillPane kills a tmux pane
 func KillPane(sessionName string, windowIndex, paneIndex int) error {
-	target := fmt.Sprintf("%s:%d.%d", sessionName, windowIndex, paneIndex)
-	return exec.Command("tmux", "kill-pane", "-t", target).Run()
+	target := PaneTarget(sessionName, windowIndex, paneIndex)
+	return run("kill-pane", "-t", target)
+}
+
+// RespawnDeadPanes restarts the command of every dead pane (left by remain-on-exit)
+// of a session, in all its windows; returns how many panes it respawned
+// Uses a single list-panes -s call; without -k tmux refuses panes that are still running
+func RespawnDeadPanes(sessionName string) (int, error) {
+	out, err := output("list-panes", "-s", "-t", ExactTarget(sessionName), "-F", "#{pane_id}\t#{pane_dead}")
+	if err != nil {
+		return 0, err
+	}
+	respawned := 0
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		id, dead, ok := strings.Cut(line, "\t")
+		if !ok || dead != "1" {
+			continue
+		}
+		if err := run("respawn-pane", "-t", id); err != nil {
+			return respawned, err
+		}
+		respawned++
+	}
+	return respawned, nil
 }
 
 // SelectPane switches to a specific pane
 func SelectPane(sessionName string, windowIndex, paneIndex int) error {
-	target := fmt.Sprintf("%s:%d.%d", sessionName, windowIndex, paneIndex)
-	return exec.Command("tmux", "switch-client", "-t", target).Run()
+	target := PaneTarget(sessionName, windowIndex, paneIndex)
+	return run("switch-client", "-t", target)
 }
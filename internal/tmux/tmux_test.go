@@ -0,0 +1,155 @@
+package tmux
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCollapseGroups(t *testing.T) {
+	sessions := []Session{
+		{Name: "work-2", Group: "work"},
+		{Name: "notes"},
+		{Name: "work", Group: "work"},
+		{Name: "work-3", Group: "work"},
+	}
+
+	got := CollapseGroups(sessions)
+	if len(got) != 2 {
+		t.Fatalf("CollapseGroups() returned %d sessions, want 2: %+v", len(got), got)
+	}
+	if got[0].Name != "work-2" || got[0].GroupSize != 3 || !slices.Equal(got[0].GroupMembers, []string{"work-2", "work", "work-3"}) {
+		t.Errorf("group representative = %+v, want work-2 with all 3 members", got[0])
+	}
+	if got[1].Name != "notes" || got[1].GroupSize != 0 {
+		t.Errorf("ungrouped session = %+v, want notes with GroupSize 0", got[1])
+	}
+}
+
+func TestCountLines(t *testing.T) {
+	got := countLines("work\nwork\nnotes\nwork\n")
+	if got["work"] != 3 || got["notes"] != 1 || len(got) != 2 {
+		t.Errorf("countLines() = %v, want work:3 notes:1", got)
+	}
+}
+
+func TestParsePaneScan(t *testing.T) {
+	out := "api\t0\t0\tnpm\t0\tbox\tbox\n" +
+		"api\t0\t1\tzsh\t0\tbox\tbox\n" +
+		"my notes\t1\t1\tnvim\t0\tbox\tnotes.md\tdraft\n" +
+		"build\t0\t0\tcargo\t1\tbox\tbox\n"
+	got := parsePaneScan(out, []string{"npm", "cargo", "nvim-qt"})
+	if !got.Running["api"] || !got.Running["build"] || got.Running["my notes"] || len(got.Running) != 2 {
+		t.Errorf("Running = %v, want api and build", got.Running)
+	}
+	if got.MarkedSession != "my notes" {
+		t.Errorf("MarkedSession = %q, want %q", got.MarkedSession, "my notes")
+	}
+
+	// The default title (the host name) is dropped; titles may contain tabs
+	if p := got.Active["api"]; p.Command != "zsh" || p.Title != "" {
+		t.Errorf("Active[api] = %+v, want zsh without title", p)
+	}
+	if p := got.Active["my notes"]; p.Command != "nvim" || p.Title != "notes.md\tdraft" {
+		t.Errorf("Active[my notes] = %+v, want nvim with title", p)
+	}
+	if _, ok := got.Active["build"]; ok || len(got.Active) != 2 {
+		t.Errorf("Active = %v, want only api and my notes", got.Active)
+	}
+	if got.Dead["build"] != 1 || len(got.Dead) != 1 {
+		t.Errorf("Dead = %v, want one dead pane in build", got.Dead)
+	}
+}
+
+func TestExactTargets(t *testing.T) {
+	// "api" is a prefix of "api-v2": only the "=" form stops tmux from matching it
+	tests := []struct {
+		got, want string
+	}{
+		{ExactTarget("api"), "=api"},
+		{ExactTarget("api:2"), "=api:2"},
+		{ExactTarget("=api"), "=api"},
+		{WindowTarget("api", 2), "=api:2"},
+		{PaneTarget("api", 2, 1), "=api:2.1"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("target = %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestSortWindowsByActivity(t *testing.T) {
+	windows := []Window{
+		{Index: 0, LastActivity: time.Unix(100, 0)},
+		{Index: 1, LastActivity: time.Unix(300, 0)},
+		{Index: 2, LastActivity: time.Unix(100, 0)},
+		{Index: 3, LastActivity: time.Unix(200, 0)},
+	}
+
+	SortWindowsByActivity(windows)
+
+	want := []int{1, 3, 0, 2}
+	for i, w := range windows {
+		if w.Index != want[i] {
+			t.Fatalf("order = %v, want indexes %v", windows, want)
+		}
+	}
+}
+
+func TestCommandError(t *testing.T) {
+	exit := errors.New("exit status 1")
+
+	err := commandError([]string{"kill-session", "-t", "=x"}, "can't find session: x\n", exit)
+	if got, want := err.Error(), "tmux kill-session: can't find session: x (exit status 1)"; got != want {
+		t.Errorf("error = %q, want %q", got, want)
+	}
+	if !errors.Is(err, exit) {
+		t.Error("stderr error should wrap the exit error")
+	}
+
+	if err := commandError([]string{"has-session"}, "", exit); err != exit {
+		t.Errorf("empty stderr: got %v, want the exit error unchanged", err)
+	}
+	if err := commandError([]string{"list-sessions"}, "warning", nil); err != nil {
+		t.Errorf("stderr on success should not fail the call, got %v", err)
+	}
+}
+
+func TestSocketArgs(t *testing.T) {
+	tests := []struct {
+		socket string
+		want   []string
+	}{
+		{"", nil},
+		{"work", []string{"-L", "work"}},
+		{"/tmp/work.sock", []string{"-S", "/tmp/work.sock"}},
+	}
+	for _, tt := range tests {
+		if got := SocketArgs(tt.socket); !slices.Equal(got, tt.want) {
+			t.Errorf("SocketArgs(%q) = %v, want %v", tt.socket, got, tt.want)
+		}
+	}
+}
+
+func TestInServer(t *testing.T) {
+	env := "/tmp/tmux-1000/work,1234,0"
+	tests := []struct {
+		env  string
+		args []string
+		want bool
+	}{
+		{"", nil, false},
+		{env, nil, true},
+		{env, SocketArgs("work"), true},
+		{env, SocketArgs("personal"), false},
+		{env, SocketArgs("/tmp/tmux-1000/work"), true},
+		{env, SocketArgs("/tmp/other.sock"), false},
+	}
+	for _, tt := range tests {
+		if got := inServer(tt.env, tt.args); got != tt.want {
+			t.Errorf("inServer(%q, %v) = %v, want %v", tt.env, tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+// Package tmuxtest provides an in-memory tmux.Client for tests
+package tmuxtest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/black-atom-industries/helm/internal/tmux"
+)
+
+// Fake is an in-memory tmux.Client for tests: sessions live in Sessions, with their
+// windows and panes nested, and calls that would change the client are recorded
+// Safe for concurrent use
+type Fake struct {
+	mu sync.Mutex
+
+	Sessions []tmux.Session
+	Switched string            // Target of the last SwitchClient call
+	Ran      []string          // RunInSession calls as "session: command", SendKeysAll as "session:window.pane: command"
+	Messages []string          // DisplayMessage calls
+	Paths    map[string]string // Working directory by session name (set by CreateSession)
+	Shells   []string          // RunShell scripts
+	Env      map[string]string // Global environment read by GlobalEnv
+}
+
+var _ tmux.Client = (*Fake)(nil)
+
+// session returns the session named name, or nil
+func (f *Fake) session(name string) *tmux.Session {
+	for i := range f.Sessions {
+		if f.Sessions[i].Name == name {
+			return &f.Sessions[i]
+		}
+	}
+	return nil
+}
+
+// window returns the window of a session with the given tmux index, or an error
+func (f *Fake) window(sessionName string, windowIndex int) (*tmux.Window, error) {
+	s := f.session(sessionName)
+	if s == nil {
+		return nil, fmt.Errorf("can't find session: %s", sessionName)
+	}
+	for i := range s.Windows {
+		if s.Windows[i].Index == windowIndex {
+			return &s.Windows[i], nil
+		}
+	}
+	return nil, fmt.Errorf("can't find window: %s:%d", sessionName, windowIndex)
+}
+
+func (f *Fake) ListSessions(excludeCurrent string, includePopups bool) ([]tmux.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var sessions []tmux.Session
+	for _, s := range f.Sessions {
+		if s.Name == excludeCurrent || (!includePopups && tmux.IsPopup(s.Name)) {
+			continue
+		}
+		// Like list-sessions, windows are loaded separately
+		s.Windows = nil
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
+
+func (f *Fake) WindowCounts() (map[string]int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	counts := make(map[string]int, len(f.Sessions))
+	for _, s := range f.Sessions {
+		counts[s.Name] = len(s.Windows)
+	}
+	return counts, nil
+}
+
+// ScanPanes treats each session's first window as the active one
+func (f *Fake) ScanPanes(commands []string) (tmux.PaneScan, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	scan := tmux.PaneScan{Running: make(map[string]bool), Active: make(map[string]tmux.Pane), Dead: make(map[string]int)}
+	for _, s := range f.Sessions {
+		for i, w := range s.Windows {
+			for _, p := range w.Panes {
+				if p.Dead {
+					scan.Dead[s.Name]++
+				}
+				for _, c := range commands {
+					if p.Command == c {
+						scan.Running[s.Name] = true
+					}
+				}
+				if i == 0 && p.Active {
+					scan.Active[s.Name] = p
+				}
+			}
+		}
+	}
+	return scan, nil
+}
+
+func (f *Fake) ListWindows(sessionName string) ([]tmux.Window, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := f.session(sessionName)
+	if s == nil {
+		return nil, fmt.Errorf("can't find session: %s", sessionName)
+	}
+	windows := make([]tmux.Window, len(s.Windows))
+	for i, w := range s.Windows {
+		w.Panes = nil
+		windows[i] = w
+	}
+	return windows, nil
+}
+
+// LastActiveWindow treats the first window as the current one and returns the
+// most recently active of the others
+func (f *Fake) LastActiveWindow(sessionName string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := f.session(sessionName)
+	if s == nil || len(s.Windows) < 2 {
+		return 0, fmt.Errorf("no other window in session %s", sessionName)
+	}
+	best := s.Windows[1]
+	for _, w := range s.Windows[2:] {
+		if w.LastActivity.After(best.LastActivity) {
+			best = w
+		}
+	}
+	return best.Index, nil
+}
+
+func (f *Fake) ListPanes(sessionName string, windowIndex int) ([]tmux.Pane, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w, err := f.window(sessionName, windowIndex)
+	if err != nil {
+		return nil, err
+	}
+	return append([]tmux.Pane{}, w.Panes...), nil
+}
+
+func (f *Fake) SessionExists(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.session(name) != nil
+}
+
+// CreateSession adds a session with one window whose pane runs command (or a shell)
+func (f *Fake) CreateSession(name, dir, command string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.session(name) != nil {
+		return fmt.Errorf("duplicate session: %s", name)
+	}
+	if command == "" {
+		command = "zsh"
+	}
+	f.Sessions = append(f.Sessions, tmux.Session{
+		Name:    name,
+		Windows: []tmux.Window{{Index: 0, Name: command, Panes: []tmux.Pane{{Index: 0, Command: command, Active: true}}}},
+	})
+	if f.Paths == nil {
+		f.Paths = make(map[string]string)
+	}
+	f.Paths[name] = dir
+	return nil
+}
+
+func (f *Fake) RunInSession(sessionName, command string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.session(sessionName) == nil {
+		return fmt.Errorf("can't find session: %s", sessionName)
+	}
+	f.Ran = append(f.Ran, sessionName+": "+command)
+	return nil
+}
+
+// SendKeysAll records command for every live pane of the session
+func (f *Fake) SendKeysAll(sessionName, command string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := f.session(sessionName)
+	if s == nil {
+		return 0, fmt.Errorf("can't find session: %s", sessionName)
+	}
+	sent := 0
+	for _, w := range s.Windows {
+		for _, p := range w.Panes {
+			if p.Dead {
+				continue
+			}
+			f.Ran = append(f.Ran, fmt.Sprintf("%s:%d.%d: %s", sessionName, w.Index, p.Index, command))
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+func (f *Fake) KillSession(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.Sessions {
+		if f.Sessions[i].Name == name {
+			f.Sessions = append(f.Sessions[:i], f.Sessions[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("can't find session: %s", name)
+}
+
+func (f *Fake) KillWindow(sessionName string, windowIndex int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.window(sessionName, windowIndex); err != nil {
+		return err
+	}
+	s := f.session(sessionName)
+	for i := range s.Windows {
+		if s.Windows[i].Index == windowIndex {
+			s.Windows = append(s.Windows[:i], s.Windows[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (f *Fake) KillPane(sessionName string, windowIndex, paneIndex int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w, err := f.window(sessionName, windowIndex)
+	if err != nil {
+		return err
+	}
+	for i := range w.Panes {
+		if w.Panes[i].Index == paneIndex {
+			w.Panes = append(w.Panes[:i], w.Panes[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("can't find pane: %s:%d.%d", sessionName, windowIndex, paneIndex)
+}
+
+// RespawnDeadPanes revives every dead pane of the session, leaving live ones alone
+func (f *Fake) RespawnDeadPanes(sessionName string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := f.session(sessionName)
+	if s == nil {
+		return 0, fmt.Errorf("can't find session: %s", sessionName)
+	}
+	respawned := 0
+	for _, w := range s.Windows {
+		for i := range w.Panes {
+			if w.Panes[i].Dead {
+				w.Panes[i].Dead = false
+				respawned++
+			}
+		}
+	}
+	return respawned, nil
+}
+
+func (f *Fake) MoveWindow(sessionName string, windowIndex int, dstSession string) error {
+	if err := f.LinkWindow(sessionName, windowIndex, dstSession); err != nil {
+		return err
+	}
+	return f.KillWindow(sessionName, windowIndex)
+}
+
+// LinkWindow copies the window into dstSession at its next free index
+func (f *Fake) LinkWindow(sessionName string, windowIndex int, dstSession string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w, err := f.window(sessionName, windowIndex)
+	if err != nil {
+		return err
+	}
+	dst := f.session(dstSession)
+	if dst == nil {
+		return fmt.Errorf("can't find session: %s", dstSession)
+	}
+	linked := *w
+	linked.Index = 0
+	for _, existing := range dst.Windows {
+		linked.Index = max(linked.Index, existing.Index+1)
+	}
+	dst.Windows = append(dst.Windows, linked)
+	return nil
+}
+
+// SwitchClient records target if its session exists
+func (f *Fake) SwitchClient(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name, _, _ := strings.Cut(strings.TrimPrefix(target, "="), ":")
+	if f.session(name) == nil {
+		return fmt.Errorf("can't find session: %s", name)
+	}
+	f.Switched = target
+	return nil
+}
+
+func (f *Fake) DisplayMessage(message string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Messages = append(f.Messages, message)
+	return nil
+}
+
+// DisplayPath returns the session's entry in Paths; every pane of a session
+// shares its directory
+func (f *Fake) DisplayPath(target string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name, _, _ := strings.Cut(target, ":")
+	if f.session(name) == nil {
+		return "", fmt.Errorf("can't find session: %s", name)
+	}
+	return f.Paths[name], nil
+}
+
+// RunShell records script instead of running it
+func (f *Fake) RunShell(script string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Shells = append(f.Shells, script)
+	return nil
+}
+
+// GlobalEnv returns name from Env, failing like tmux when it's unset
+func (f *Fake) GlobalEnv(name string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	value, ok := f.Env[name]
+	if !ok {
+		return "", fmt.Errorf("unknown variable: %s", name)
+	}
+	return value, nil
+}
@@ -56,6 +56,11 @@ type FgColors struct {
 	ClaudeWaiting lipgloss.TerminalColor // "?" icon
 	ClaudeUrgent  lipgloss.TerminalColor // "!" icon
 
+	// Activity indicator
+	ActivityHot  lipgloss.TerminalColor // Active within ActivityHotThreshold
+	ActivityWarm lipgloss.TerminalColor // Active within ActivityWarmThreshold
+	ActivityCold lipgloss.TerminalColor // Older activity
+
 	// Git status
 	GitFiles lipgloss.TerminalColor // File count
 	GitAdd   lipgloss.TerminalColor // Additions
@@ -67,6 +72,13 @@ type BgColors struct {
 	Default  lipgloss.TerminalColor // Terminal default (none)
 	TitleBar lipgloss.TerminalColor // Title bar background
 	Selected lipgloss.TerminalColor // Selected row background
+
+	// Title bar view name badge, so each kind of view is recognizable at a glance
+	ViewSessions  lipgloss.TerminalColor // Session list and its prompts (go to, log, settings)
+	ViewBookmarks lipgloss.TerminalColor // Bookmarks
+	ViewPick      lipgloss.TerminalColor // Directory picker, new session, move window
+	ViewClone     lipgloss.TerminalColor // Clone repo
+	ViewDanger    lipgloss.TerminalColor // Kill, delete and quit confirmations
 }
 
 // Colors is the global color configuration
@@ -94,6 +106,10 @@ var Colors = struct {
 		ClaudeWaiting: green,
 		ClaudeUrgent:  red,
 
+		ActivityHot:  green,
+		ActivityWarm: yellow,
+		ActivityCold: brightBlack,
+
 		GitFiles: hexGitBlue,
 		GitAdd:   hexGitGreen,
 		GitDel:   hexGitRed,
@@ -102,5 +118,11 @@ var Colors = struct {
 		Default:  lipgloss.NoColor{},
 		TitleBar: black,
 		Selected: black,
+
+		ViewSessions:  blue,
+		ViewBookmarks: cyan,
+		ViewPick:      green,
+		ViewClone:     magenta,
+		ViewDanger:    red,
 	},
 }
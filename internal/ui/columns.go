@@ -15,6 +15,12 @@ import (
 type RowLayout struct {
 	NameWidth      int
 	GitStatusWidth int
+	IconWidth      int // Project icon column (0 = hidden)
+	CountWidth     int // Window count column after the expand icon (0 = hidden)
+	WatchWidth     int // Watch glyph column after the name (0 = hidden)
+	PaneMarkWidth  int // Marked pane column after the watch glyph (0 = hidden)
+	StarWidth      int // Favorite star column after the marked pane (0 = hidden)
+	DeadWidth      int // Dead pane count column after the star (0 = hidden)
 }
 
 // RowOpts contains options for rendering a generic row
@@ -34,17 +40,33 @@ type RowOpts struct {
 	GitStatusLoading bool           // Show loading indicator for git status
 	ClaudeStatus     *claude.Status // Show claude status if set
 	AnimFrame        int            // Animation frame for claude status
+	ShowActivity     bool           // Show activity dot before time ago (needs LastActivity)
+	ClaudeWait       time.Duration  // When "waiting" escalates to ! (0 = ClaudeWaitThreshold)
+	Created          *time.Time     // Show session age if set
+	ProjectIcon      string         // Project type icon (needs layout.IconWidth)
+	WindowCount      int            // Window count next to the expand icon (needs layout.CountWidth)
+	Marked           bool           // Marked for a batch action (replaces the index)
+	Watch            string         // Glyph for a session running a watched command (needs layout.WatchWidth)
+	PaneMarked       bool           // Session holds tmux's marked pane (needs layout.PaneMarkWidth)
+	Starred          bool           // Favorite session (needs layout.StarWidth)
+	DeadPanes        int            // Panes kept by remain-on-exit after their process died (needs layout.DeadWidth)
+	PaneInfo         string         // Active pane's command and title (e.g. "nvim · notes.md")
+	Popup            bool           // A _popup_ session (listed with helm --all or C-v): name dimmed
+	Env              string         // Pinned runtime versions badge, shown last (e.g. "node@20 python@3.12")
 }
 
 // WindowRowOpts contains per-row options for rendering a window
 type WindowRowOpts struct {
 	Selected bool
 	Expanded bool // Window is expanded to show panes
+	Marked   bool // Marked for a batch action (replaces the expand icon)
 }
 
 // PaneRowOpts contains per-row options for rendering a pane
 type PaneRowOpts struct {
 	Selected bool
+	Marked   bool // Marked for a batch action (replaces the active marker)
+	Dead     bool // Process exited (remain-on-exit): "dead" follows the command
 }
 
 // Column component functions - each returns a styled string
@@ -57,7 +79,7 @@ func SpacerStyle(text string, selected bool) string {
 	return text
 }
 
-// RenderIndex renders the session number (1-9)
+// RenderIndex renders the session number (every row; 0-9 jump directly, C-f any)
 func RenderIndex(num int, selected bool) string {
 	label := fmt.Sprintf("%d", num)
 	if selected {
@@ -66,6 +88,17 @@ func RenderIndex(num int, selected bool) string {
 	return IndexStyle.Render(label)
 }
 
+// MarkedIcon marks rows selected for a batch action (Space)
+const MarkedIcon = "✓"
+
+// RenderMarked renders the mark shown in place of a row's first column
+func RenderMarked(selected bool) string {
+	if selected {
+		return MarkedSelectedStyle.Render(MarkedIcon)
+	}
+	return MarkedStyle.Render(MarkedIcon)
+}
+
 // RenderExpandIcon renders the expand/collapse indicator
 func RenderExpandIcon(expanded, selected bool) string {
 	if expanded {
@@ -89,11 +122,177 @@ func RenderName(name string, width int, selected bool, selectedStyle, defaultSty
 	return defaultStyle.Render(padded)
 }
 
+// RenderProjectIcon renders the project type icon padded to width
+// Returns "" when the column is hidden (width 0)
+func RenderProjectIcon(icon string, width int, selected bool) string {
+	if width == 0 {
+		return ""
+	}
+	padded := icon + strings.Repeat(" ", max(0, width-lipgloss.Width(icon)))
+	if selected {
+		return TimeSelectedStyle.Render(padded) + SpacerStyle(" ", selected)
+	}
+	return TimeStyle.Render(padded) + " "
+}
+
+// RenderWatchGlyph renders the watch glyph column (with its leading space) padded to width
+// Returns "" when the column is hidden (width 0)
+func RenderWatchGlyph(glyph string, width int, selected bool) string {
+	if width == 0 {
+		return ""
+	}
+	padded := glyph + strings.Repeat(" ", max(0, width-lipgloss.Width(glyph)))
+	if selected {
+		return SpacerStyle(" ", selected) + WatchSelectedStyle.Render(padded)
+	}
+	return " " + WatchStyle.Render(padded)
+}
+
+// PaneMarkGlyph flags the session holding tmux's marked pane (select-pane -m)
+const PaneMarkGlyph = "⚑"
+
+// RenderPaneMark renders the marked pane column (with its leading space)
+// Returns "" when the column is hidden (width 0)
+func RenderPaneMark(marked bool, width int, selected bool) string {
+	glyph := ""
+	if marked {
+		glyph = PaneMarkGlyph
+	}
+	return RenderWatchGlyph(glyph, width, selected)
+}
+
+// StarGlyph flags favorite sessions (C-z)
+const StarGlyph = "★"
+
+// RenderStar renders the favorite star column (with its leading space)
+// Returns "" when the column is hidden (width 0)
+func RenderStar(starred bool, width int, selected bool) string {
+	glyph := ""
+	if starred {
+		glyph = StarGlyph
+	}
+	return RenderWatchGlyph(glyph, width, selected)
+}
+
+// DeadPaneGlyph precedes the number of dead panes in a session (remain-on-exit)
+const DeadPaneGlyph = "✗"
+
+// DeadPanesLabel returns the dead pane column text, e.g. "✗2" ("" for none)
+func DeadPanesLabel(count int) string {
+	if count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s%d", DeadPaneGlyph, count)
+}
+
+// RenderDeadPanes renders the dead pane count column (with its leading space)
+// Returns "" when the column is hidden (width 0)
+func RenderDeadPanes(count, width int, selected bool) string {
+	if width == 0 {
+		return ""
+	}
+	label := DeadPanesLabel(count)
+	padded := label + strings.Repeat(" ", max(0, width-lipgloss.Width(label)))
+	if selected {
+		return SpacerStyle(" ", selected) + DeadPaneSelectedStyle.Render(padded)
+	}
+	return " " + DeadPaneStyle.Render(padded)
+}
+
+// flagColumns returns blank space for the watch, marked pane, star and dead pane columns of rows without them
+func flagColumns(layout RowLayout) string {
+	width := 0
+	if layout.WatchWidth > 0 {
+		width += layout.WatchWidth + 1
+	}
+	if layout.PaneMarkWidth > 0 {
+		width += layout.PaneMarkWidth + 1
+	}
+	if layout.StarWidth > 0 {
+		width += layout.StarWidth + 1
+	}
+	if layout.DeadWidth > 0 {
+		width += layout.DeadWidth + 1
+	}
+	return strings.Repeat(" ", width)
+}
+
+// RenderEnvBadge renders the pinned runtime versions badge
+func RenderEnvBadge(badge string, selected bool) string {
+	if selected {
+		return TimeSelectedStyle.Render(badge)
+	}
+	return TimeStyle.Render(badge)
+}
+
+// PaneInfoMaxWidth caps the pane command and title so long titles don't crowd the row
+const PaneInfoMaxWidth = 32
+
+// RenderPaneInfo renders the active pane's command and title, truncated to PaneInfoMaxWidth
+func RenderPaneInfo(info string, selected bool) string {
+	if runes := []rune(info); len(runes) > PaneInfoMaxWidth {
+		info = string(runes[:PaneInfoMaxWidth-1]) + "…"
+	}
+	if selected {
+		return TimeSelectedStyle.Render(info)
+	}
+	return TimeStyle.Render(info)
+}
+
+// SessionLabel returns the name shown for a session: grouped sessions note how
+// many other sessions of their tmux group are folded into the row, e.g. "work [+2]"
+func SessionLabel(name string, groupSize int) string {
+	if groupSize > 1 {
+		return fmt.Sprintf("%s [+%d]", name, groupSize-1)
+	}
+	return name
+}
+
+// GroupHeaderPrefix starts the header line above a tmux session group's row
+const GroupHeaderPrefix = "⧉ group"
+
+// RenderGroupHeader renders the dimmed line above a session group's row, naming
+// the group and its sessions, e.g. "⧉ group work: work-2, work, work-3"
+func RenderGroupHeader(group string, members []string, width int) string {
+	header := fmt.Sprintf("%s %s: %s", GroupHeaderPrefix, group, strings.Join(members, ", "))
+	return TimeStyle.Render(TruncateLine(header, width))
+}
+
+// RenderWindowCount renders a session's window count right-aligned to width
+// A zero (unknown) count renders as blank space
+func RenderWindowCount(count, width int, selected bool) string {
+	label := ""
+	if count > 0 {
+		label = fmt.Sprintf("%d", count)
+	}
+	padded := fmt.Sprintf("%*s", width, label)
+	if selected {
+		return TimeSelectedStyle.Render(padded)
+	}
+	return TimeStyle.Render(padded)
+}
+
+// countColumn returns blank space for the window count column of rows without one
+func countColumn(layout RowLayout) string {
+	if layout.CountWidth == 0 {
+		return ""
+	}
+	return strings.Repeat(" ", layout.CountWidth+1)
+}
+
 // RenderSessionName renders the session name
 func RenderSessionName(name string, width int, selected bool) string {
 	return RenderName(name, width, selected, SessionNameSelectedStyle, SessionNameStyle)
 }
 
+// renderRowName renders a session row's name, dimmed for popup sessions
+func renderRowName(name string, width int, opts RowOpts) string {
+	if opts.Popup {
+		return RenderName(name, width, opts.Selected, SessionNameSelectedStyle, PopupSessionNameStyle)
+	}
+	return RenderSessionName(name, width, opts.Selected)
+}
+
 // RenderWindowName renders the window name with index
 func RenderWindowName(index int, name string, selected bool) string {
 	text := fmt.Sprintf("%d: %s", index, name)
@@ -103,6 +302,25 @@ func RenderWindowName(index int, name string, selected bool) string {
 	return WindowNameStyle.Render(text)
 }
 
+// RepoDetail formats "[lang] description" for the clone list, truncated to width runes
+func RepoDetail(language, description string, width int) string {
+	detail := description
+	if language != "" {
+		detail = strings.TrimSpace("[" + language + "] " + description)
+	}
+	runes := []rune(detail)
+	if width <= 0 {
+		return ""
+	}
+	if len(runes) > width {
+		if width == 1 {
+			return "…"
+		}
+		return string(runes[:width-1]) + "…"
+	}
+	return detail
+}
+
 // RenderTimeAgo renders the time since last activity
 func RenderTimeAgo(t time.Time, selected bool) string {
 	timeAgo := FormatTimeAgo(t)
@@ -113,6 +331,53 @@ func RenderTimeAgo(t time.Time, selected bool) string {
 	return TimeStyle.Render(padded)
 }
 
+// RenderAge renders how long ago a session was created (e.g. "3d")
+func RenderAge(t time.Time, selected bool) string {
+	padded := fmt.Sprintf("%-4s", FormatAge(t))
+	if selected {
+		return TimeSelectedStyle.Render(padded)
+	}
+	return TimeStyle.Render(padded)
+}
+
+// FormatAge formats a creation time as a compact age string without the "ago" suffix
+func FormatAge(t time.Time) string {
+	return strings.TrimSuffix(FormatTimeAgo(t), " ago")
+}
+
+// Activity thresholds for the activity indicator dot
+const (
+	ActivityHotThreshold  = 5 * time.Minute
+	ActivityWarmThreshold = time.Hour
+)
+
+// ActivityIndicator returns a colored dot for how recently a session was active:
+// green within ActivityHotThreshold, yellow within ActivityWarmThreshold, dim otherwise
+func ActivityIndicator(t time.Time) string {
+	return activityStyle(time.Since(t)).Render("●")
+}
+
+// RenderActivityIndicator renders the activity dot with selection background
+func RenderActivityIndicator(t time.Time, selected bool) string {
+	style := activityStyle(time.Since(t))
+	if selected {
+		style = style.Background(Colors.Bg.Selected)
+	}
+	return style.Render("●")
+}
+
+// activityStyle picks the dot style for the time elapsed since last activity
+func activityStyle(d time.Duration) lipgloss.Style {
+	switch {
+	case d < ActivityHotThreshold:
+		return ActivityHotStyle
+	case d < ActivityWarmThreshold:
+		return ActivityWarmStyle
+	default:
+		return ActivityColdStyle
+	}
+}
+
 // FormatTimeAgo formats a time as a human-readable "X ago" string
 func FormatTimeAgo(t time.Time) string {
 	d := time.Since(t)
@@ -162,12 +427,12 @@ func RenderGitStatusColumn(status *git.Status, maxWidth int, selected bool, load
 
 // RenderClaudeIcon renders a single-character Claude status icon
 // Returns a space for no status to preserve column alignment
-func RenderClaudeIcon(status *claude.Status, animFrame int, selected bool) string {
+func RenderClaudeIcon(status *claude.Status, animFrame int, waitThreshold time.Duration, selected bool) string {
 	if status == nil || status.State == "" {
 		return SpacerStyle(" ", selected) // Reserved space for alignment
 	}
 	waitDuration := time.Since(status.Timestamp)
-	icon := FormatClaudeIcon(status.State, animFrame, waitDuration)
+	icon := FormatClaudeIcon(status.State, animFrame, waitDuration, waitThreshold)
 	if selected {
 		// Re-apply the icon style with background
 		return lipgloss.NewStyle().Background(Colors.Bg.Selected).Render(icon)
@@ -183,24 +448,43 @@ type SessionRowOpts struct {
 // RenderSessionRow composes all columns into a complete session row
 func RenderSessionRow(name string, lastActivity time.Time, layout RowLayout, opts SessionRowOpts, width int) string {
 	cols := []string{
-		RenderIndex(opts.Num, opts.Selected),
+		renderIndexOrMark(opts.RowOpts),
 		SpacerStyle(" ", opts.Selected),
 		// Claude icon (after index, before expand arrow)
-		RenderClaudeIcon(opts.ClaudeStatus, opts.AnimFrame, opts.Selected),
+		RenderClaudeIcon(opts.ClaudeStatus, opts.AnimFrame, opts.ClaudeWait, opts.Selected),
 		SpacerStyle(" ", opts.Selected),
 	}
 
-	// Expand icon (optional)
+	// Expand icon (optional), with the window count next to it
 	if opts.ShowExpandIcon {
 		cols = append(cols, RenderExpandIcon(opts.Expanded, opts.Selected), SpacerStyle(" ", opts.Selected))
+		if layout.CountWidth > 0 {
+			cols = append(cols, RenderWindowCount(opts.WindowCount, layout.CountWidth, opts.Selected), SpacerStyle(" ", opts.Selected))
+		}
 	}
 
-	// Name (always shown)
-	cols = append(cols, RenderSessionName(name, layout.NameWidth, opts.Selected))
+	// Name (always shown), with optional project icon in front
+	cols = append(cols,
+		RenderProjectIcon(opts.ProjectIcon, layout.IconWidth, opts.Selected),
+		renderRowName(name, layout.NameWidth, opts.RowOpts),
+		RenderWatchGlyph(opts.Watch, layout.WatchWidth, opts.Selected),
+		RenderPaneMark(opts.PaneMarked, layout.PaneMarkWidth, opts.Selected),
+		RenderStar(opts.Starred, layout.StarWidth, opts.Selected),
+		RenderDeadPanes(opts.DeadPanes, layout.DeadWidth, opts.Selected),
+	)
 
-	// Time ago (optional)
+	// Time ago (optional), with optional activity dot in front
 	if opts.LastActivity != nil {
-		cols = append(cols, SpacerStyle("  ", opts.Selected), RenderTimeAgo(*opts.LastActivity, opts.Selected))
+		cols = append(cols, SpacerStyle("  ", opts.Selected))
+		if opts.ShowActivity {
+			cols = append(cols, RenderActivityIndicator(*opts.LastActivity, opts.Selected), SpacerStyle(" ", opts.Selected))
+		}
+		cols = append(cols, RenderTimeAgo(*opts.LastActivity, opts.Selected))
+	}
+
+	// Session age (optional)
+	if opts.Created != nil {
+		cols = append(cols, SpacerStyle(" ", opts.Selected), RenderAge(*opts.Created, opts.Selected))
 	}
 
 	// Git status (optional column)
@@ -208,6 +492,16 @@ func RenderSessionRow(name string, lastActivity time.Time, layout RowLayout, opt
 		cols = append(cols, SpacerStyle(" ", opts.Selected), RenderGitStatusColumn(opts.GitStatus, layout.GitStatusWidth, opts.Selected, opts.GitStatusLoading, opts.AnimFrame))
 	}
 
+	// Active pane command and title (optional, unaligned like the env badge)
+	if opts.PaneInfo != "" {
+		cols = append(cols, SpacerStyle(" ", opts.Selected), RenderPaneInfo(opts.PaneInfo, opts.Selected))
+	}
+
+	// Env versions badge (optional, last so it needs no alignment)
+	if opts.Env != "" {
+		cols = append(cols, SpacerStyle(" ", opts.Selected), RenderEnvBadge(opts.Env, opts.Selected))
+	}
+
 	content := strings.Join(cols, "")
 	if opts.Selected {
 		return SessionSelectedStyle.Width(width).Render(content)
@@ -215,14 +509,92 @@ func RenderSessionRow(name string, lastActivity time.Time, layout RowLayout, opt
 	return SessionStyle.Width(width).Render(content)
 }
 
+// RenderCompactSessionRow composes a compact session row: just index and name
+// Used in compact mode where the time/git/claude columns are dropped
+func RenderCompactSessionRow(name string, opts RowOpts, width int) string {
+	cols := []string{
+		renderIndexOrMark(opts),
+		SpacerStyle(" ", opts.Selected),
+		renderRowName(name, 0, opts),
+	}
+
+	content := strings.Join(cols, "")
+	if opts.Selected {
+		return SessionSelectedStyle.Width(width).Render(content)
+	}
+	return SessionStyle.Width(width).Render(content)
+}
+
+// renderIndexOrMark renders the row number, or the mark for marked rows
+func renderIndexOrMark(opts RowOpts) string {
+	if opts.Marked {
+		// Pad to the index column's width (3)
+		return RenderMarked(opts.Selected) + SpacerStyle("  ", opts.Selected)
+	}
+	return RenderIndex(opts.Num, opts.Selected)
+}
+
+// CurrentSessionMarker marks the current session row in the index column
+const CurrentSessionMarker = "●"
+
+// RenderCurrentSessionRow renders the current session as a dimmed, non-selectable
+// "you are here" row aligned with the session columns
+func RenderCurrentSessionRow(name string, lastActivity time.Time, layout RowLayout, width int) string {
+	icon := ""
+	if layout.IconWidth > 0 {
+		icon = strings.Repeat(" ", layout.IconWidth+1) // Project icon column
+	}
+	content := fmt.Sprintf("%-3s %s %s %s%s%-*s%s  %-8s you are here",
+		CurrentSessionMarker,
+		" ", // Claude icon column
+		" ", // Expand icon column
+		countColumn(layout),
+		icon,
+		layout.NameWidth, name,
+		flagColumns(layout),
+		FormatTimeAgo(lastActivity),
+	)
+	return CurrentSessionStyle.Width(width).Render(content)
+}
+
+// OpenSessionTag follows directory picker entries that already have a session
+const OpenSessionTag = "● open"
+
+// RecentProjectMarker fills the expand icon column of recent project rows
+const RecentProjectMarker = "+"
+
+// RenderRecentProjectRow renders a recently opened project without a session,
+// aligned with the session columns; selecting it creates the session
+func RenderRecentProjectRow(num int, name string, layout RowLayout, selected bool, width int) string {
+	icon := ""
+	if layout.IconWidth > 0 {
+		icon = strings.Repeat(" ", layout.IconWidth+1) // Project icon column
+	}
+	content := fmt.Sprintf("%-3d %s %s %s%s%-*s%s  %s",
+		num,
+		" ", // Claude icon column
+		RecentProjectMarker,
+		countColumn(layout),
+		icon,
+		layout.NameWidth, name,
+		flagColumns(layout),
+		"recent",
+	)
+	if selected {
+		return SessionSelectedStyle.Width(width).Render(content)
+	}
+	return RecentProjectStyle.Width(width).Render(content)
+}
+
 // RenderBookmarkRow composes a bookmark row (simpler than session row)
 func RenderBookmarkRow(name string, layout RowLayout, opts RowOpts, width int) string {
 	cols := []string{
 		RenderIndex(opts.Num, opts.Selected),
 		SpacerStyle(" ", opts.Selected),
 		// Claude icon column (always reserved for alignment with session rows)
-		RenderClaudeIcon(opts.ClaudeStatus, opts.AnimFrame, opts.Selected),
+		RenderClaudeIcon(opts.ClaudeStatus, opts.AnimFrame, opts.ClaudeWait, opts.Selected),
 		SpacerStyle(" ", opts.Selected),
+		RenderProjectIcon(opts.ProjectIcon, layout.IconWidth, opts.Selected),
 		RenderSessionName(name, layout.NameWidth, opts.Selected),
 	}
 
@@ -242,6 +614,8 @@ func RenderBookmarkRow(name string, layout RowLayout, opts RowOpts, width int) s
 type TableHeaderOpts struct {
 	ShowExpandIcon bool
 	ShowTime       bool
+	ShowActivity   bool // Reserve space for the activity dot before the time column
+	ShowAge        bool
 	ShowGit        bool
 	NameLabel      string // e.g., "Session" or "Bookmark"
 }
@@ -258,9 +632,14 @@ func RenderTableHeader(layout RowLayout, opts TableHeaderOpts) string {
 		" ",
 	}
 
-	// Expand icon placeholder
+	// Expand icon and window count placeholders
 	if opts.ShowExpandIcon {
-		cols = append(cols, " ", " ")
+		cols = append(cols, " ", " ", countColumn(layout))
+	}
+
+	// Project icon placeholder
+	if layout.IconWidth > 0 {
+		cols = append(cols, strings.Repeat(" ", layout.IconWidth+1))
 	}
 
 	// Name column header
@@ -268,11 +647,20 @@ func RenderTableHeader(layout RowLayout, opts TableHeaderOpts) string {
 	if nameLabel == "" {
 		nameLabel = "NAME"
 	}
-	cols = append(cols, dim.Render(fmt.Sprintf("%-*s", layout.NameWidth, nameLabel)))
+	cols = append(cols, dim.Render(fmt.Sprintf("%-*s", layout.NameWidth, nameLabel)), flagColumns(layout))
 
 	// Time column header
 	if opts.ShowTime {
-		cols = append(cols, "  ", dim.Render(fmt.Sprintf("%-8s", "ACT")))
+		cols = append(cols, "  ")
+		if opts.ShowActivity {
+			cols = append(cols, "  ")
+		}
+		cols = append(cols, dim.Render(fmt.Sprintf("%-8s", "ACT")))
+	}
+
+	// Age column header
+	if opts.ShowAge {
+		cols = append(cols, " ", dim.Render(fmt.Sprintf("%-4s", "AGE")))
 	}
 
 	// Git column header
@@ -289,7 +677,11 @@ func RenderWindowRow(index int, name string, opts WindowRowOpts, width int) stri
 	var parts []string
 
 	// Expand icon for windows (shows if window can be expanded to show panes)
-	parts = append(parts, RenderExpandIcon(opts.Expanded, opts.Selected))
+	if opts.Marked {
+		parts = append(parts, RenderMarked(opts.Selected))
+	} else {
+		parts = append(parts, RenderExpandIcon(opts.Expanded, opts.Selected))
+	}
 	parts = append(parts, SpacerStyle(" ", opts.Selected))
 
 	// Window name with index
@@ -310,7 +702,13 @@ func RenderPaneRow(index int, command string, active bool, opts PaneRowOpts, wid
 	if active {
 		activeMarker = "*"
 	}
+	if opts.Marked {
+		activeMarker = MarkedIcon
+	}
 	text := fmt.Sprintf("%s %d: %s", activeMarker, index, command)
+	if opts.Dead {
+		text += " (dead)"
+	}
 
 	if opts.Selected {
 		return PaneSelectedStyle.Width(width).Render(text)
@@ -318,25 +716,25 @@ func RenderPaneRow(index int, command string, active bool, opts PaneRowOpts, wid
 	return PaneStyle.Width(width).Render(text)
 }
 
-// ItemDepth represents the hierarchy level of an item
-// This design allows easy extension for panes (helm-xdn)
+// ItemDepth represents the hierarchy level of an item: session → window → pane
 type ItemDepth int
 
 const (
 	DepthSession ItemDepth = 0
 	DepthWindow  ItemDepth = 1
-	DepthPane    ItemDepth = 2 // Future use
+	DepthPane    ItemDepth = 2
 )
 
 // IndentForDepth returns the left padding for a given depth level
+// Window and pane row styles are indented by it
 func IndentForDepth(depth ItemDepth) int {
 	switch depth {
 	case DepthSession:
 		return 0
 	case DepthWindow:
-		return 10 // Matches current WindowStyle.PaddingLeft
+		return 10 // Past the session row's index, claude icon and expand icon
 	case DepthPane:
-		return 14 // Further indented for panes
+		return 14 // Under the window name, past its expand icon and index
 	default:
 		return 0
 	}
@@ -1,6 +1,12 @@
 package ui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
 
 // KeyMap defines all key bindings for the application
 type KeyMap struct {
@@ -8,14 +14,40 @@ type KeyMap struct {
 	Down          key.Binding
 	Expand        key.Binding
 	Collapse      key.Binding
+	ExpandAll     key.Binding
+	CollapseAll   key.Binding
 	Select        key.Binding
 	Kill          key.Binding
 	Create        key.Binding
+	Duplicate     key.Binding
+	Mark          key.Binding
+	Yank          key.Binding
+	KillRemoveDir key.Binding
 	PickDirectory key.Binding
 	CloneRepo     key.Binding
 	Lazygit       key.Binding
+	FileManager   key.Binding
+	PullRequests  key.Binding
+	Issues        key.Binding
 	Bookmarks     key.Binding
 	AddBookmark   key.Binding
+	ToggleCompact key.Binding
+	TogglePopups  key.Binding
+	ToggleStar    key.Binding
+	ShowAll       key.Binding
+	ToggleGit     key.Binding
+	ToggleClaude  key.Binding
+	ClearClaude   key.Binding
+	RespawnDead   key.Binding
+	Broadcast     key.Binding
+	Refresh       key.Binding
+	MoveWindow    key.Binding
+	MessageLog    key.Binding
+	GoTo          key.Binding
+	Settings      key.Binding
+	EditConfig    key.Binding
+	LinkWindow    key.Binding
+	CloneURL      key.Binding
 	Quit          key.Binding
 	Cancel        key.Binding
 	Confirm       key.Binding
@@ -50,6 +82,14 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("ctrl+h", "left"),
 		key.WithHelp("←", "Collapse"),
 	),
+	ExpandAll: key.NewBinding(
+		key.WithKeys("shift+right"),
+		key.WithHelp("S-→", "Expand all"),
+	),
+	CollapseAll: key.NewBinding(
+		key.WithKeys("shift+left"),
+		key.WithHelp("S-←", "Collapse all"),
+	),
 	Select: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "Switch"),
@@ -62,6 +102,22 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("ctrl+n"),
 		key.WithHelp("C-n", "New"),
 	),
+	Duplicate: key.NewBinding(
+		key.WithKeys("ctrl+d"),
+		key.WithHelp("C-d", "Duplicate"),
+	),
+	KillRemoveDir: key.NewBinding(
+		key.WithKeys("ctrl+d"),
+		key.WithHelp("C-d", "Kill + delete dir"),
+	),
+	Yank: key.NewBinding(
+		key.WithKeys("ctrl+y"),
+		key.WithHelp("C-y", "Yank switch command"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("Spc", "Mark"),
+	),
 	PickDirectory: key.NewBinding(
 		key.WithKeys("ctrl+p"),
 		key.WithHelp("C-p", "Projects"),
@@ -74,6 +130,18 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("ctrl+g"),
 		key.WithHelp("C-g", "Lazygit"),
 	),
+	FileManager: key.NewBinding(
+		key.WithKeys("ctrl+w"),
+		key.WithHelp("C-w", "Files"),
+	),
+	PullRequests: key.NewBinding(
+		key.WithKeys("ctrl+e"),
+		key.WithHelp("C-e", "PRs"),
+	),
+	Issues: key.NewBinding(
+		key.WithKeys("ctrl+u"),
+		key.WithHelp("C-u", "Issues"),
+	),
 	Bookmarks: key.NewBinding(
 		key.WithKeys("ctrl+b"),
 		key.WithHelp("C-b", "Bookmarks"),
@@ -82,6 +150,74 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("ctrl+a"),
 		key.WithHelp("C-a", "Add bookmark"),
 	),
+	ToggleCompact: key.NewBinding(
+		key.WithKeys("ctrl+t"),
+		key.WithHelp("C-t", "Compact"),
+	),
+	TogglePopups: key.NewBinding(
+		key.WithKeys("ctrl+v"),
+		key.WithHelp("C-v", "Popups"),
+	),
+	ToggleStar: key.NewBinding(
+		key.WithKeys("ctrl+z"),
+		key.WithHelp("C-z", "Star"),
+	),
+	ShowAll: key.NewBinding(
+		key.WithKeys("f3"),
+		key.WithHelp("F3", "All sessions"),
+	),
+	ToggleGit: key.NewBinding(
+		key.WithKeys("f6"),
+		key.WithHelp("F6", "Git column"),
+	),
+	ToggleClaude: key.NewBinding(
+		key.WithKeys("f7"),
+		key.WithHelp("F7", "Claude status"),
+	),
+	ClearClaude: key.NewBinding(
+		key.WithKeys("f8"),
+		key.WithHelp("F8", "Clear Claude"),
+	),
+	RespawnDead: key.NewBinding(
+		key.WithKeys("f9"),
+		key.WithHelp("F9", "Respawn dead"),
+	),
+	Broadcast: key.NewBinding(
+		key.WithKeys("f4"),
+		key.WithHelp("F4", "Send to all panes"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("f5"),
+		key.WithHelp("F5", "Refresh"),
+	),
+	MoveWindow: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("C-o", "Move window"),
+	),
+	MessageLog: key.NewBinding(
+		key.WithKeys("f2"),
+		key.WithHelp("F2", "Messages"),
+	),
+	GoTo: key.NewBinding(
+		key.WithKeys("ctrl+f"),
+		key.WithHelp("C-f", "Go to #"),
+	),
+	Settings: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("C-s", "Settings"),
+	),
+	EditConfig: key.NewBinding(
+		key.WithKeys("ctrl+e"),
+		key.WithHelp("C-e", "Edit file"),
+	),
+	LinkWindow: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("Tab", "Link"),
+	),
+	CloneURL: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("Tab", "URL"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("ctrl+c"),
 		key.WithHelp("C-c", "Quit"),
@@ -116,19 +252,95 @@ func helpSep() string {
 	return HelpSepStyle.Render(" · ")
 }
 
-// HelpNormal returns the help text for normal mode (two lines)
-func HelpNormal() string {
-	line1 := helpItem("Type", "filter") + helpSep() +
-		helpItem("C-j/k | ↑↓", "Nav") + helpSep() +
-		helpItem("C-h/l | ←→", "Expand") + helpSep() +
-		helpItem("C-x", "Kill")
-	line2 := helpItem("C-n", "New") + helpSep() +
-		helpItem("C-p", "Projects") + helpSep() +
-		helpItem("C-b", "Bookmarks") + helpSep() +
-		helpItem("C-a", "Bookmark") + helpSep() +
-		helpItem("C-r", "Clone") + helpSep() +
-		helpItem("C-g", "Lazygit")
-	return line1 + "\n" + line2
+// bindingItem formats a binding's own help (key + description) as a help item
+func bindingItem(b key.Binding) string {
+	return helpItem(b.Help().Key, b.Help().Desc)
+}
+
+// pairItem formats two related bindings as one help item, sharing a modifier
+// prefix: "C-n" and "C-d" become "C-n/d", "S-←" and "S-→" become "S-←→"
+func pairItem(a, b key.Binding, desc string) string {
+	ka, kb := a.Help().Key, b.Help().Key
+	if i := strings.LastIndex(ka, "-") + 1; i > 0 && strings.HasPrefix(kb, ka[:i]) {
+		return helpItem(ka[:i]+joinKeys(ka[i:], kb[i:]), desc)
+	}
+	return helpItem(joinKeys(ka, kb), desc)
+}
+
+// joinKeys joins two key names, with a slash unless both are single symbols like arrows
+func joinKeys(a, b string) string {
+	if utf8.RuneCountInString(a) == 1 && utf8.RuneCountInString(b) == 1 && a > "\x7f" && b > "\x7f" {
+		return a + b
+	}
+	return a + "/" + b
+}
+
+// wrapHelp joins help items with separators, starting a new line when the next
+// item wouldn't fit in width; items beyond HintsHeight lines are left out
+func wrapHelp(items []string, width int) string {
+	var lines []string
+	line := ""
+	for _, item := range items {
+		switch {
+		case line == "":
+			line = item
+		case lipgloss.Width(line+helpSep()+item) <= width:
+			line += helpSep() + item
+		default:
+			lines = append(lines, line)
+			line = item
+		}
+		if len(lines) == HintsHeight {
+			break
+		}
+	}
+	if line != "" && len(lines) < HintsHeight {
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// HelpFeatures are the optional parts of helm the normal-mode help mentions
+type HelpFeatures struct {
+	ReadOnly bool // helm monitor: no marking or killing
+	Projects bool // project_dirs is set: project picker and clone
+	GitHub   bool // gh is installed: PR and issue lists
+	Popups   bool // disable_popups is off: lazygit, file manager and GitHub popups
+}
+
+// HelpNormal returns the help text for normal mode, built from the key map's
+// bindings and wrapped into HintsHeight lines of width cells
+// Keys of disabled features are left out, so the help matches what works
+func HelpNormal(keys KeyMap, features HelpFeatures, width int) string {
+	items := []string{
+		helpItem("Type", "filter"),
+		pairItem(keys.Up, keys.Down, "Nav"),
+		pairItem(keys.Collapse, keys.Expand, "Expand"),
+		pairItem(keys.CollapseAll, keys.ExpandAll, "All"),
+		bindingItem(keys.GoTo),
+	}
+	if !features.ReadOnly {
+		items = append(items, bindingItem(keys.Mark), bindingItem(keys.Kill))
+	}
+	items = append(items,
+		bindingItem(keys.ToggleStar),
+		bindingItem(keys.ToggleCompact),
+		pairItem(keys.Create, keys.Duplicate, "New/Dup"),
+	)
+	if features.Projects {
+		items = append(items, bindingItem(keys.PickDirectory))
+	}
+	items = append(items, bindingItem(keys.Bookmarks), bindingItem(keys.AddBookmark))
+	if features.Projects {
+		items = append(items, bindingItem(keys.CloneRepo))
+	}
+	if features.Popups {
+		items = append(items, pairItem(keys.Lazygit, keys.FileManager, "Lazygit/Files"))
+	}
+	if features.Popups && features.GitHub {
+		items = append(items, pairItem(keys.PullRequests, keys.Issues, "PRs/Issues"))
+	}
+	return wrapHelp(items, width)
 }
 
 // HelpFiltering returns the help text when filter is active
@@ -146,24 +358,63 @@ func HelpFilteringNoResults() string {
 }
 
 // HelpConfirmKill returns the help text for kill confirmation mode
-func HelpConfirmKill() string {
-	return helpItem("C-x", "Confirm") + helpSep() +
+// canRemoveDir adds the kill-and-delete-directory choice (single session only)
+func HelpConfirmKill(canRemoveDir bool) string {
+	hints := helpItem("C-x", "Confirm") + helpSep()
+	if canRemoveDir {
+		hints += helpItem("C-d", "Kill + delete dir") + helpSep()
+	}
+	return hints + helpItem("Esc", "Cancel")
+}
+
+// HelpConfirmQuit returns the help text for the warn_on_quit prompt
+func HelpConfirmQuit() string {
+	return helpItem("C-c/Enter", "Quit") + helpSep() +
+		helpItem("Esc", "Cancel")
+}
+
+// HelpConfirmClearClaude returns the help text for the clear-Claude-statuses prompt
+func HelpConfirmClearClaude() string {
+	return helpItem("C-x/Enter", "Delete all") + helpSep() +
+		helpItem("Esc", "Cancel")
+}
+
+// HelpBroadcast returns the help text for sending a command to all panes
+// confirming switches from typing the command to the confirmation
+func HelpBroadcast(confirming bool) string {
+	if confirming {
+		return helpItem("C-x/Enter", "Send") + helpSep() +
+			helpItem("Esc", "Edit")
+	}
+	return helpItem("Type", "command") + helpSep() +
+		helpItem("Enter", "Review") + helpSep() +
+		helpItem("Esc", "Cancel")
+}
+
+// HelpGoTo returns the help text for the go-to-session-number prompt
+func HelpGoTo() string {
+	return helpItem("0-9", "Number") + helpSep() +
+		helpItem("Enter", "Switch") + helpSep() +
 		helpItem("Esc", "Cancel")
 }
 
 // HelpCreate returns the help text for create mode
 func HelpCreate() string {
 	return helpItem("Enter", "Create") + helpSep() +
+		helpItem("name -- cmd", "Run") + helpSep() +
+		helpItem("↑↓", "History") + helpSep() +
 		helpItem("Esc", "Cancel")
 }
 
 // HelpPickDirectory returns the help text for directory picker mode
-func HelpPickDirectory() string {
-	return helpItem("C-j/k | ↑↓", "Nav") + helpSep() +
+func HelpPickDirectory(readOnly bool) string {
+	help := helpItem("C-j/k | ↑↓", "Nav") + helpSep() +
 		helpItem("Enter", "Select") + helpSep() +
-		helpItem("C-a", "Bookmark") + helpSep() +
-		helpItem("C-x", "Remove") + helpSep() +
-		helpItem("Esc", "Back")
+		helpItem("C-a", "Bookmark") + helpSep()
+	if !readOnly {
+		help += helpItem("C-x", "Remove") + helpSep()
+	}
+	return help + helpItem("Esc", "Back")
 }
 
 // HelpPickDirectoryCreate returns the help text when creating a session from filter
@@ -197,9 +448,21 @@ func HelpConfirmRemoveFolder() string {
 func HelpCloneRepo() string {
 	return helpItem("C-j/k | ↑↓", "Nav") + helpSep() +
 		helpItem("Enter", "Clone") + helpSep() +
+		helpItem("Tab", "URL") + helpSep() +
 		helpItem("Esc", "Back/Cancel")
 }
 
+// HelpCloneURL returns the help text for cloning from a typed URL
+// canList adds the key back to the repo list (false when gh is missing)
+func HelpCloneURL(canList bool) string {
+	help := helpItem("Type/paste", "git URL") + helpSep() +
+		helpItem("Enter", "Clone") + helpSep()
+	if canList {
+		help += helpItem("Tab", "List") + helpSep()
+	}
+	return help + helpItem("Esc", "Back/Cancel")
+}
+
 // HelpCloneRepoLoading returns the help text while loading repos
 func HelpCloneRepoLoading() string {
 	return helpItem("Esc", "Cancel")
@@ -211,13 +474,41 @@ func HelpCloneSuccess() string {
 		helpItem("Esc", "Back to sessions")
 }
 
-// HelpBookmarks returns the help text for bookmarks mode
-func HelpBookmarks() string {
+// HelpMessageLog returns the help text for the message log view
+func HelpMessageLog() string {
+	return helpItem("C-j/k | ↑↓", "Scroll") + helpSep() +
+		helpItem("Esc | F2", "Close")
+}
+
+// HelpSettings returns the help text for the settings view
+// canEdit adds the config editor popup (left out with disable_popups)
+func HelpSettings(canEdit bool) string {
+	help := helpItem("C-j/k | ↑↓", "Nav") + helpSep() +
+		helpItem("Enter", "Toggle") + helpSep() +
+		helpItem("C-h/l | ←→", "Change") + helpSep()
+	if canEdit {
+		help += helpItem("C-e", "Edit file") + helpSep()
+	}
+	return help + helpItem("Esc | C-s", "Close")
+}
+
+// HelpMoveWindow returns the help text for the move/link window target picker
+func HelpMoveWindow() string {
 	return helpItem("C-j/k | ↑↓", "Nav") + helpSep() +
+		helpItem("Enter", "Move") + helpSep() +
+		helpItem("Tab", "Link") + helpSep() +
+		helpItem("Esc", "Cancel")
+}
+
+// HelpBookmarks returns the help text for bookmarks mode
+func HelpBookmarks(readOnly bool) string {
+	help := helpItem("C-j/k | ↑↓", "Nav") + helpSep() +
 		helpItem("C-h/l | ←→", "Expand") + helpSep() +
 		helpItem("Enter", "Open") + helpSep() +
 		helpItem("C-p/n", "Move") + helpSep() +
-		helpItem("C-a", "Add") + helpSep() +
-		helpItem("C-x", "Remove") + helpSep() +
-		helpItem("Esc", "Back")
+		helpItem("C-a", "Add") + helpSep()
+	if !readOnly {
+		help += helpItem("C-x", "Remove") + helpSep()
+	}
+	return help + helpItem("Esc", "Back")
 }
@@ -8,23 +8,27 @@ const (
 	TitleBarHeight  = 1
 	PromptHeight    = 1
 	TopBorderHeight = 1
-	HeaderOverhead  = 3 // TitleBarHeight + PromptHeight + TopBorderHeight
+	HeaderOverhead  = TitleBarHeight + PromptHeight + TopBorderHeight
 
 	// Footer section (below content)
 	BottomBorderHeight = 1
 	NotificationHeight = 1
 	StateLineHeight    = 1
 	HintsHeight        = 2
-	FooterOverhead     = 5 // BottomBorderHeight + NotificationHeight + StateLineHeight + HintsHeight
+	FooterOverhead     = BottomBorderHeight + NotificationHeight + StateLineHeight + HintsHeight
 
 	// Optional content elements
 	TableHeaderHeight     = 1
 	TableDottedLineHeight = 1
 
 	// Computed totals for visible items calculation
-	BaseOverhead            = 8  // HeaderOverhead + FooterOverhead
-	WithTableHeaderOverhead = 10 // BaseOverhead + TableHeaderHeight + TableDottedLineHeight
+	BaseOverhead            = HeaderOverhead + FooterOverhead
+	WithTableHeaderOverhead = BaseOverhead + TableHeaderHeight + TableDottedLineHeight
 
 	// Fallback values
 	DefaultVisibleItems = 10
+
+	// Minimum terminal size for a usable layout (below this a notice is shown instead)
+	MinUsableWidth  = 30
+	MinUsableHeight = BaseOverhead + AppBorderOverheadY + 1 // Room for at least one row
 )
@@ -129,6 +129,10 @@ func (s *ScrollList[T]) updateScrollOffset() {
 	if s.cursor >= s.scrollOffset+s.height {
 		s.scrollOffset = s.cursor - s.height + 1
 	}
+	// Don't leave blank rows at the bottom, e.g. after growing taller
+	if s.scrollOffset > len(s.filtered)-s.height {
+		s.scrollOffset = len(s.filtered) - s.height
+	}
 	// Ensure scroll offset is not negative
 	if s.scrollOffset < 0 {
 		s.scrollOffset = 0
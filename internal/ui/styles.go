@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // Border and padding overhead for the app container
@@ -43,6 +44,10 @@ var (
 				Foreground(Colors.Fg.Error).
 				Padding(0, 1)
 
+	// Error text without padding (inline use, e.g. message log)
+	ErrorTextStyle = lipgloss.NewStyle().
+			Foreground(Colors.Fg.Error)
+
 	// Session row styles
 	SessionStyle = lipgloss.NewStyle().
 			Padding(0, 1)
@@ -55,22 +60,22 @@ var (
 	// Window row styles (indented)
 	WindowStyle = lipgloss.NewStyle().
 			Padding(0, 1).
-			PaddingLeft(10)
+			PaddingLeft(IndentForDepth(DepthWindow))
 
 	WindowSelectedStyle = lipgloss.NewStyle().
 				Padding(0, 1).
-				PaddingLeft(10).
+				PaddingLeft(IndentForDepth(DepthWindow)).
 				Bold(true).
 				Background(Colors.Bg.Selected)
 
 	// Pane row styles (further indented)
 	PaneStyle = lipgloss.NewStyle().
 			Padding(0, 1).
-			PaddingLeft(14)
+			PaddingLeft(IndentForDepth(DepthPane))
 
 	PaneSelectedStyle = lipgloss.NewStyle().
 				Padding(0, 1).
-				PaddingLeft(14).
+				PaddingLeft(IndentForDepth(DepthPane)).
 				Bold(true).
 				Background(Colors.Bg.Selected)
 
@@ -85,6 +90,15 @@ var (
 				Bold(true).
 				Width(3)
 
+	MarkedStyle = lipgloss.NewStyle().
+			Foreground(Colors.Fg.Accent).
+			Bold(true)
+
+	MarkedSelectedStyle = lipgloss.NewStyle().
+				Foreground(Colors.Fg.Accent).
+				Background(Colors.Bg.Selected).
+				Bold(true)
+
 	SessionNameStyle = lipgloss.NewStyle().
 				Foreground(Colors.Fg.SessionName)
 
@@ -93,6 +107,11 @@ var (
 					Background(Colors.Bg.Selected).
 					Bold(true)
 
+	// Popup session names (_popup_*, only listed on request), dimmed like leftovers
+	PopupSessionNameStyle = lipgloss.NewStyle().
+				Foreground(Colors.Fg.Muted).
+				Italic(true)
+
 	WindowNameStyle = lipgloss.NewStyle().
 			Foreground(Colors.Fg.WindowName)
 
@@ -109,11 +128,54 @@ var (
 	TimeStyle = lipgloss.NewStyle().
 			Foreground(Colors.Fg.Muted)
 
+	// Project picker root prefix (which project_dirs entry a directory came from)
+	ProjectRootStyle = lipgloss.NewStyle().
+				Foreground(Colors.Fg.Muted)
+
+	// Watch glyph after sessions running a watch_commands entry
+	WatchStyle = lipgloss.NewStyle().
+			Foreground(Colors.Fg.Accent)
+	WatchSelectedStyle = lipgloss.NewStyle().
+				Foreground(Colors.Fg.Accent).
+				Background(Colors.Bg.Selected)
+
+	// Dead pane count after sessions with panes kept by remain-on-exit
+	DeadPaneStyle = lipgloss.NewStyle().
+			Foreground(Colors.Fg.Error)
+	DeadPaneSelectedStyle = lipgloss.NewStyle().
+				Foreground(Colors.Fg.Error).
+				Background(Colors.Bg.Selected)
+
+	// "● open" tag on picker entries that already have a session
+	OpenSessionStyle = lipgloss.NewStyle().
+				Foreground(Colors.Fg.Muted)
+
+	// Recent project row (no session yet; shown above the sessions)
+	RecentProjectStyle = lipgloss.NewStyle().
+				Padding(0, 1).
+				Foreground(Colors.Fg.Muted)
+
+	// Current session row ("you are here", shown dimmed and not selectable)
+	CurrentSessionStyle = lipgloss.NewStyle().
+				Padding(0, 1).
+				Foreground(Colors.Fg.Muted).
+				Italic(true)
+
 	TimeSelectedStyle = lipgloss.NewStyle().
 				Foreground(Colors.Fg.Muted).
 				Background(Colors.Bg.Selected).
 				Bold(true)
 
+	// Activity indicator styles
+	ActivityHotStyle = lipgloss.NewStyle().
+				Foreground(Colors.Fg.ActivityHot)
+
+	ActivityWarmStyle = lipgloss.NewStyle().
+				Foreground(Colors.Fg.ActivityWarm)
+
+	ActivityColdStyle = lipgloss.NewStyle().
+				Foreground(Colors.Fg.ActivityCold)
+
 	// Claude status styles
 	ClaudeNewStyle = lipgloss.NewStyle().
 			Foreground(Colors.Fg.Muted)
@@ -211,7 +273,8 @@ func RenderDottedBorder(width int) string {
 }
 
 // RenderTitleBar renders the inverted title bar with logo on left and view name on right
-func RenderTitleBar(logo, viewName string, width int) string {
+// The view name is a badge on the accent background (one of the Colors.Bg.View* colors)
+func RenderTitleBar(logo, viewName string, accent lipgloss.TerminalColor, width int) string {
 	// Account for padding in AppStyle (1 on each side)
 	innerWidth := width - AppBorderOverheadX
 	if innerWidth < 10 {
@@ -219,13 +282,14 @@ func RenderTitleBar(logo, viewName string, width int) string {
 	}
 
 	// Calculate spacing between logo and view name
-	spacing := innerWidth - len(logo) - len(viewName) - 2 // -2 for padding spaces
+	spacing := innerWidth - len(logo) - len(viewName) - 3 // -3 for the padding spaces around logo and badge
 	if spacing < 1 {
 		spacing = 1
 	}
 
-	content := " " + logo + strings.Repeat(" ", spacing) + viewName + " "
-	return TitleBarStyle.Width(innerWidth).Render(content)
+	bar := TitleBarStyle.Render(" " + logo + strings.Repeat(" ", spacing))
+	badge := TitleBarStyle.Background(accent).Render(" " + viewName + " ")
+	return bar + badge
 }
 
 // RenderPrompt renders the prompt line with optional filter text
@@ -238,7 +302,14 @@ func RenderPrompt(filter string, width int) string {
 	return PromptStyle.Width(innerWidth).Render(prompt)
 }
 
-// RenderFooter renders the 3-line footer (notification, state, hints)
+// TruncateLine cuts s (which may contain styling) to width cells, ending in "…"
+// Lines are cut rather than wrapped so views keep the heights layout.go counts on
+func TruncateLine(s string, width int) string {
+	return ansi.Truncate(s, max(0, width), "…")
+}
+
+// RenderFooter renders the footer: border, notification, state and HintsHeight hint lines
+// Every line is cut to the width, so the footer is always FooterOverhead lines tall
 func RenderFooter(notification, state, hints string, isError bool, width int) string {
 	innerWidth := width - AppBorderOverheadX
 	if innerWidth < 10 {
@@ -250,8 +321,12 @@ func RenderFooter(notification, state, hints string, isError bool, width int) st
 	b.WriteString(RenderBorder(innerWidth))
 	b.WriteString("\n")
 
+	// Text width inside the styles' horizontal padding
+	textWidth := innerWidth - 2
+
 	// Notification line (always 1 line, even if empty)
 	if notification != "" {
+		notification = TruncateLine(notification, textWidth)
 		if isError {
 			b.WriteString(ErrorMessageStyle.Width(innerWidth).Render(notification))
 		} else {
@@ -264,14 +339,24 @@ func RenderFooter(notification, state, hints string, isError bool, width int) st
 
 	// State line (always 1 line, even if empty)
 	if state != "" {
-		b.WriteString(StateStyle.Width(innerWidth).Render(state))
+		b.WriteString(StateStyle.Width(innerWidth).Render(TruncateLine(state, textWidth)))
 	} else {
 		b.WriteString(strings.Repeat(" ", innerWidth))
 	}
 	b.WriteString("\n")
 
-	// Hints line
-	b.WriteString(FooterStyle.Width(innerWidth).Render(hints))
+	// Hint lines (always HintsHeight lines)
+	lines := strings.Split(hints, "\n")
+	for i := range HintsHeight {
+		line := ""
+		if i < len(lines) {
+			line = TruncateLine(lines[i], textWidth)
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(FooterStyle.Width(innerWidth).Render(line))
+	}
 
 	return b.String()
 }
@@ -280,12 +365,17 @@ func RenderFooter(notification, state, hints string, isError bool, width int) st
 // Uses bottom 4 dots (positions 2,3,5,6) for better vertical alignment
 var ClaudeSpinnerFrames = []string{"⠤", "⠆", "⠒", "⠰"}
 
-// ClaudeWaitThreshold is the duration after which "waiting" escalates from ? to !
+// ClaudeWaitThreshold is the default duration after which "waiting" escalates from ? to !
 const ClaudeWaitThreshold = 5 * time.Minute
 
 // FormatClaudeIcon formats the Claude status as a single character icon
 // animationFrame cycles 0-3 for the spinner, waitDuration determines ? vs !
-func FormatClaudeIcon(state string, animationFrame int, waitDuration time.Duration) string {
+// waitThreshold <= 0 means ClaudeWaitThreshold
+func FormatClaudeIcon(state string, animationFrame int, waitDuration, waitThreshold time.Duration) string {
+	if waitThreshold <= 0 {
+		waitThreshold = ClaudeWaitThreshold
+	}
+
 	switch state {
 	case "new":
 		// Don't show icon for "new" - it's just noise
@@ -296,7 +386,7 @@ func FormatClaudeIcon(state string, animationFrame int, waitDuration time.Durati
 		return ClaudeWorkingStyle.Render(ClaudeSpinnerFrames[frame])
 	case "waiting":
 		// Escalate from ? to ! after threshold
-		if waitDuration >= ClaudeWaitThreshold {
+		if waitDuration >= waitThreshold {
 			return ClaudeWaitingUrgentStyle.Render("!")
 		}
 		return ClaudeWaitingStyle.Render("?")
@@ -4,6 +4,9 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestFormatClaudeIcon(t *testing.T) {
@@ -104,7 +107,7 @@ func TestFormatClaudeIcon(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := FormatClaudeIcon(tt.state, tt.animationFrame, tt.waitDuration)
+			result := FormatClaudeIcon(tt.state, tt.animationFrame, tt.waitDuration, 0)
 
 			if tt.wantSpace && result != " " {
 				t.Errorf("FormatClaudeIcon(%q, %d, %v) = %q, want space", tt.state, tt.animationFrame, tt.waitDuration, result)
@@ -122,6 +125,15 @@ func TestFormatClaudeIcon(t *testing.T) {
 	}
 }
 
+func TestFormatClaudeIconWaitThreshold(t *testing.T) {
+	if got := FormatClaudeIcon("waiting", 0, 2*time.Minute, time.Minute); !strings.Contains(got, "!") {
+		t.Errorf("custom threshold exceeded: got %q, want !", got)
+	}
+	if got := FormatClaudeIcon("waiting", 0, 2*time.Minute, 10*time.Minute); !strings.Contains(got, "?") {
+		t.Errorf("custom threshold not reached: got %q, want ?", got)
+	}
+}
+
 func TestScrollbarChars(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -228,3 +240,117 @@ func TestRenderBorder(t *testing.T) {
 		}
 	}
 }
+
+func TestActivityStyle(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		want lipgloss.Style
+	}{
+		{name: "just now is hot", d: 0, want: ActivityHotStyle},
+		{name: "under 5m is hot", d: 4 * time.Minute, want: ActivityHotStyle},
+		{name: "5m is warm", d: 5 * time.Minute, want: ActivityWarmStyle},
+		{name: "under 1h is warm", d: 59 * time.Minute, want: ActivityWarmStyle},
+		{name: "1h is cold", d: time.Hour, want: ActivityColdStyle},
+		{name: "days ago is cold", d: 72 * time.Hour, want: ActivityColdStyle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := activityStyle(tt.d)
+			if got.GetForeground() != tt.want.GetForeground() {
+				t.Errorf("activityStyle(%v) foreground = %v, want %v", tt.d, got.GetForeground(), tt.want.GetForeground())
+			}
+		})
+	}
+}
+
+func TestActivityIndicator(t *testing.T) {
+	if !strings.Contains(ActivityIndicator(time.Now()), "●") {
+		t.Error("ActivityIndicator should render a dot")
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	got := FormatAge(time.Now().Add(-3 * 24 * time.Hour))
+	if got != "3d" {
+		t.Errorf("FormatAge(3 days ago) = %q, want %q", got, "3d")
+	}
+}
+
+func TestRepoDetail(t *testing.T) {
+	tests := []struct {
+		name        string
+		language    string
+		description string
+		width       int
+		want        string
+	}{
+		{name: "language and description", language: "Go", description: "A TUI", width: 40, want: "[Go] A TUI"},
+		{name: "language only", language: "Go", width: 40, want: "[Go]"},
+		{name: "description only", description: "A TUI", width: 40, want: "A TUI"},
+		{name: "truncated", language: "Go", description: "A long description", width: 10, want: "[Go] A lo…"},
+		{name: "no room", language: "Go", description: "A TUI", width: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RepoDetail(tt.language, tt.description, tt.width); got != tt.want {
+				t.Errorf("RepoDetail() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderWindowCount(t *testing.T) {
+	if got := lipgloss.Width(RenderWindowCount(3, 2, false)); got != 2 {
+		t.Errorf("width = %d, want 2", got)
+	}
+	if got := RenderWindowCount(12, 2, false); !strings.Contains(got, "12") {
+		t.Errorf("RenderWindowCount(12) = %q, want it to contain 12", got)
+	}
+	if got := RenderWindowCount(0, 2, false); strings.TrimSpace(got) != "" {
+		t.Errorf("unknown count should render blank, got %q", got)
+	}
+}
+
+func TestRenderTitleBarWidth(t *testing.T) {
+	for _, view := range []string{"SESS", "CLONE", "KILL"} {
+		bar := RenderTitleBar("HELM", view, Colors.Bg.ViewDanger, 60)
+		if got, want := lipgloss.Width(bar), 60-AppBorderOverheadX; got != want {
+			t.Errorf("%s: width = %d, want %d", view, got, want)
+		}
+		if !strings.Contains(bar, "HELM") || !strings.Contains(bar, view+" ") {
+			t.Errorf("%s: bar %q should show the logo and the view name", view, bar)
+		}
+	}
+}
+
+func TestHelpNormal(t *testing.T) {
+	full := ansi.Strip(HelpNormal(DefaultKeyMap, HelpFeatures{Projects: true, GitHub: true, Popups: true}, 200))
+	for _, want := range []string{"↑↓ Nav", "S-←→ All", "C-n/d New/Dup", "C-x Kill", "C-e/u PRs/Issues"} {
+		if !strings.Contains(full, want) {
+			t.Errorf("help %q is missing %q", full, want)
+		}
+	}
+
+	if noPopups := ansi.Strip(HelpNormal(DefaultKeyMap, HelpFeatures{GitHub: true}, 200)); strings.Contains(noPopups, "Lazygit") || strings.Contains(noPopups, "PRs") {
+		t.Errorf("help %q should leave out the popups", noPopups)
+	}
+
+	limited := ansi.Strip(HelpNormal(DefaultKeyMap, HelpFeatures{ReadOnly: true}, 60))
+	lines := strings.Split(limited, "\n")
+	if len(lines) != HintsHeight {
+		t.Fatalf("got %d lines, want %d", len(lines), HintsHeight)
+	}
+	for _, line := range lines {
+		if lipgloss.Width(line) > 60 {
+			t.Errorf("line %q is wider than 60", line)
+		}
+	}
+	for _, unwanted := range []string{"Kill", "PRs", "Projects"} {
+		if strings.Contains(limited, unwanted) {
+			t.Errorf("help %q should leave out %q", limited, unwanted)
+		}
+	}
+}